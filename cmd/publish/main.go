@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hacker-news/config"
+	"hacker-news/internal/publish"
+	"hacker-news/internal/storage"
+	"log"
+	"time"
+)
+
+// episodeContent 与internal/api/handlers.go中的同名结构保持一致，
+// 是存储在对象存储中的每日内容对象的结构
+type episodeContent struct {
+	Intro      string   `json:"intro"`
+	Podcast    string   `json:"podcast"`
+	Blog       string   `json:"blog"`
+	AudioURL   string   `json:"audioUrl"`
+	AudioFiles []string `json:"audioFiles,omitempty"`
+	Duration   int      `json:"duration,omitempty"`
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	date := flag.String("date", time.Now().Format("2006-01-02"), "要分发的内容日期，格式YYYY-MM-DD")
+	flag.Parse()
+
+	log.Printf("开始分发 %s 的内容", *date)
+
+	cfg := config.LoadConfig()
+
+	publishers := publish.BuildPublishers(cfg.Publish)
+	if len(publishers) == 0 {
+		log.Println("未启用任何分发渠道，退出")
+		return
+	}
+	multiPublisher := publish.NewMultiPublisher(publishers)
+
+	storageClient, err := storage.Factory(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("创建存储客户端失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	contentKey := fmt.Sprintf("content:%s:hacker-news:%s", cfg.Server.Env, *date)
+	data, err := storageClient.DownloadFile(ctx, contentKey)
+	if err != nil {
+		log.Fatalf("下载 %s 的内容失败: %v", *date, err)
+	}
+
+	var content episodeContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		log.Fatalf("解析 %s 的内容失败: %v", *date, err)
+	}
+
+	if content.AudioURL != "" {
+		podcastInfo := publish.PodcastInfo{
+			Date:     *date,
+			Title:    fmt.Sprintf("Hacker News 播客 %s", *date),
+			Summary:  content.Intro,
+			AudioURL: content.AudioURL,
+		}
+		if err := multiPublisher.PublishPodcast(ctx, podcastInfo); err != nil {
+			log.Printf("分发播客失败: %v", err)
+		} else {
+			log.Println("播客分发成功")
+		}
+	} else {
+		log.Printf("%s 暂无音频，跳过播客分发", *date)
+	}
+
+	if content.Blog != "" {
+		blogInfo := publish.BlogInfo{
+			Date:    *date,
+			Title:   fmt.Sprintf("Hacker News 日报 %s", *date),
+			Content: content.Blog,
+		}
+		if err := multiPublisher.PublishBlog(ctx, blogInfo); err != nil {
+			log.Printf("分发博客失败: %v", err)
+		} else {
+			log.Println("博客分发成功")
+		}
+	}
+
+	log.Printf("%s 的内容分发完成", *date)
+}
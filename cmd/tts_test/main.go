@@ -40,7 +40,7 @@ func main() {
 		
 		// 调用TTS服务
 		startTime := time.Now()
-		audio, err := edgeTTS.SynthesizeSpeech(ctx, text, speaker)
+		audio, err := edgeTTS.Synthesize(ctx, tts.SynthesisRequest{Text: text, Speaker: speaker})
 		duration := time.Since(startTime)
 		
 		if err != nil {
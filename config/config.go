@@ -1,9 +1,12 @@
 package config
 
 import (
+	"encoding/json"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,9 +22,12 @@ func init() {
 type Config struct {
 	Server     ServerConfig
 	OpenAI     OpenAIConfig
-	MinIO      MinIOConfig
+	Storage    StorageConfig
+	Cache      CacheConfig
 	HackerNews HackerNewsConfig
 	TTS        TTSConfig
+	Feed       FeedConfig
+	Publish    PublishConfig
 }
 
 // ServerConfig 服务器配置
@@ -39,6 +45,28 @@ type OpenAIConfig struct {
 	MaxTokens      int
 	DefaultAPIKey  string
 	DefaultBaseURL string
+	Credentials    []CredentialConfig // 多凭证路由，为空时退化为BaseURL/APIKey/Model单凭证
+}
+
+// CredentialConfig 是单个AI服务凭证的配置，支持混用不同服务商
+// （如DeepSeek + OpenAI兼容端点），由ai.Client按权重轮询并在额度耗尽/请求失败时故障转移
+type CredentialConfig struct {
+	Name             string `json:"name"`
+	BaseURL          string `json:"baseUrl"`
+	APIKey           string `json:"apiKey"`
+	Model            string `json:"model"`
+	Weight           int    `json:"weight"`           // 加权轮询权重，<=0按1处理
+	DailyTokenBudget int    `json:"dailyTokenBudget"` // 每日token预算，<=0表示不限制
+}
+
+// StorageConfig 对象存储配置，Provider决定实际使用哪个驱动，
+// 各子结构体只在对应Provider被选中时生效
+type StorageConfig struct {
+	Provider string // "minio", "s3", "oss", "local"
+	MinIO    MinIOConfig
+	S3       S3Config
+	OSS      OSSConfig
+	Local    LocalStorageConfig
 }
 
 // MinIOConfig MinIO存储配置
@@ -49,17 +77,72 @@ type MinIOConfig struct {
 	SecretAccessKey string
 }
 
+// S3Config AWS S3（或兼容S3协议的第三方服务）存储配置
+type S3Config struct {
+	Region          string
+	BucketName      string
+	AccessKeyID     string
+	SecretAccessKey string
+	Endpoint        string // 可选，自建/兼容S3协议的endpoint，留空则使用AWS官方endpoint
+}
+
+// OSSConfig 阿里云OSS存储配置
+type OSSConfig struct {
+	Endpoint        string
+	BucketName      string
+	AccessKeyID     string
+	AccessKeySecret string
+}
+
+// LocalStorageConfig 本地文件系统存储配置，用于开发/CI环境
+type LocalStorageConfig struct {
+	BaseDir string // 文件落盘的根目录
+	BaseURL string // 对外提供访问的基础URL，例如 http://localhost:3001
+	SignKey string // 生成/校验签名URL使用的密钥
+}
+
+// CacheConfig 缓存子系统配置，Provider决定实际使用哪个后端，
+// DefaultTTL是调用方未显式指定时长时使用的默认过期时间
+type CacheConfig struct {
+	Provider   string // "memory", "redis", "memcached"
+	DefaultTTL time.Duration
+	Memory     MemoryCacheConfig
+	Redis      RedisCacheConfig
+	Memcached  MemcachedCacheConfig
+}
+
+// MemoryCacheConfig 进程内LRU缓存配置
+type MemoryCacheConfig struct {
+	MaxEntries int
+}
+
+// RedisCacheConfig Redis缓存配置
+type RedisCacheConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// MemcachedCacheConfig Memcached缓存配置
+type MemcachedCacheConfig struct {
+	Addrs []string // 服务器地址列表，支持一致性哈希分片
+}
+
 // HackerNewsConfig Hacker News相关配置
 type HackerNewsConfig struct {
-	JinaKey  string
-	MaxItems int
+	Source          string // 新闻源实现，"html"（抓取网页，默认）或"firebase"（官方Firebase API）
+	JinaKey         string
+	MaxItems        int
+	ArticleCacheDir string // 提取后的正文按URL哈希缓存到该目录，留空表示不启用磁盘缓存
 }
 
 // TTSConfig 文本转语音配置
 type TTSConfig struct {
-	Provider  string // "edge", "aliyun", 等
-	EdgeTTS   EdgeTTSConfig
-	AliyunTTS AliyunTTSConfig
+	Provider   string // "edge", "aliyun", "tencent", "iflytek", 等
+	EdgeTTS    EdgeTTSConfig
+	AliyunTTS  AliyunTTSConfig
+	TencentTTS TencentTTSConfig
+	IFlytekTTS IFlytekTTSConfig
 }
 
 // EdgeTTSConfig Edge TTS配置
@@ -75,6 +158,66 @@ type AliyunTTSConfig struct {
 	VoiceID         string
 }
 
+// TencentTTSConfig 腾讯云GME语音合成及语音审核配置
+type TencentTTSConfig struct {
+	SecretId            string
+	SecretKey           string
+	Region              string
+	AppID               string
+	VoiceID             string
+	ModerationThreshold float64 // 审核分数阈值(0-1)，超过即拒绝发布
+}
+
+// IFlytekTTSConfig 科大讯飞WebSocket语音合成配置
+type IFlytekTTSConfig struct {
+	AppID     string
+	APIKey    string
+	APISecret string
+	VoiceName string
+}
+
+// FeedConfig 播客RSS订阅源的频道级配置
+type FeedConfig struct {
+	Title       string
+	Description string
+	Link        string
+	Image       string
+	Category    string
+	Explicit    bool
+	OwnerEmail  string
+}
+
+// PublishConfig 分发子系统配置，Channels决定启用哪些渠道，
+// 每个渠道下可配置多组凭证/机器人，发布时对同一渠道的所有凭证扇出
+type PublishConfig struct {
+	Channels []string // 启用的渠道，如 "wechat"、"dingtalk"
+	WeChat   WeChatPublishConfig
+	DingTalk DingTalkPublishConfig
+}
+
+// WeChatCredential 是一个微信公众号的AppID/AppSecret凭证对
+type WeChatCredential struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// WeChatPublishConfig 微信公众号分发配置，支持同时配置多个公众号
+type WeChatPublishConfig struct {
+	Credentials []WeChatCredential
+}
+
+// DingTalkBot 是一个钉钉自定义机器人的Webhook配置
+type DingTalkBot struct {
+	Name    string `json:"name"` // 机器人名称，仅用于日志和错误归因
+	Webhook string `json:"webhook"`
+	Secret  string `json:"secret"` // 加签密钥，留空表示该机器人未启用加签校验
+}
+
+// DingTalkPublishConfig 钉钉机器人分发配置，支持同时配置多个机器人
+type DingTalkPublishConfig struct {
+	Bots []DingTalkBot
+}
+
 // LoadConfig 从环境变量加载配置
 func LoadConfig() *Config {
 	return &Config{
@@ -90,16 +233,55 @@ func LoadConfig() *Config {
 			MaxTokens:      getEnvIntOrDefault("OPENAI_MAX_TOKENS", 4096),
 			DefaultAPIKey:  "sk-b5195ce322244754b2c87d901473070e", // 测试用，生产环境应使用环境变量
 			DefaultBaseURL: "https://api.deepseek.com/v1",
+			Credentials:    getEnvCredentialsOrDefault("OPENAI_CREDENTIALS", nil),
 		},
-		MinIO: MinIOConfig{
-			Endpoint:        getEnvOrDefault("HACKER_NEWS_R2_BUCKET_URL", "http://localhost:9000"),
-			BucketName:      getEnvOrDefault("HACKER_NEWS_BUCKET_NAME", "hacker-news"),
-			AccessKeyID:     getEnvOrDefault("MINIO_ACCESS_KEY", "minioadmin"),
-			SecretAccessKey: getEnvOrDefault("MINIO_SECRET_KEY", "minioadmin"),
+		Storage: StorageConfig{
+			Provider: getEnvOrDefault("STORAGE_PROVIDER", "minio"),
+			MinIO: MinIOConfig{
+				Endpoint:        getEnvOrDefault("HACKER_NEWS_R2_BUCKET_URL", "http://localhost:9000"),
+				BucketName:      getEnvOrDefault("HACKER_NEWS_BUCKET_NAME", "hacker-news"),
+				AccessKeyID:     getEnvOrDefault("MINIO_ACCESS_KEY", "minioadmin"),
+				SecretAccessKey: getEnvOrDefault("MINIO_SECRET_KEY", "minioadmin"),
+			},
+			S3: S3Config{
+				Region:          getEnvOrDefault("S3_REGION", "us-east-1"),
+				BucketName:      getEnvOrDefault("S3_BUCKET_NAME", "hacker-news"),
+				AccessKeyID:     getEnvOrDefault("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: getEnvOrDefault("S3_SECRET_ACCESS_KEY", ""),
+				Endpoint:        getEnvOrDefault("S3_ENDPOINT", ""),
+			},
+			OSS: OSSConfig{
+				Endpoint:        getEnvOrDefault("OSS_ENDPOINT", ""),
+				BucketName:      getEnvOrDefault("OSS_BUCKET_NAME", "hacker-news"),
+				AccessKeyID:     getEnvOrDefault("OSS_ACCESS_KEY_ID", ""),
+				AccessKeySecret: getEnvOrDefault("OSS_ACCESS_KEY_SECRET", ""),
+			},
+			Local: LocalStorageConfig{
+				BaseDir: getEnvOrDefault("LOCAL_STORAGE_DIR", "./data/storage"),
+				BaseURL: getEnvOrDefault("LOCAL_STORAGE_BASE_URL", "http://localhost:3001"),
+				SignKey: getEnvOrDefault("LOCAL_STORAGE_SIGN_KEY", "dev-local-storage-key"),
+			},
+		},
+		Cache: CacheConfig{
+			Provider:   getEnvOrDefault("CACHE_PROVIDER", "memory"),
+			DefaultTTL: getEnvDurationOrDefault("CACHE_DEFAULT_TTL", 24*time.Hour),
+			Memory: MemoryCacheConfig{
+				MaxEntries: getEnvIntOrDefault("CACHE_MEMORY_MAX_ENTRIES", 1000),
+			},
+			Redis: RedisCacheConfig{
+				Addr:     getEnvOrDefault("CACHE_REDIS_ADDR", "localhost:6379"),
+				Password: getEnvOrDefault("CACHE_REDIS_PASSWORD", ""),
+				DB:       getEnvIntOrDefault("CACHE_REDIS_DB", 0),
+			},
+			Memcached: MemcachedCacheConfig{
+				Addrs: getEnvListOrDefault("CACHE_MEMCACHED_ADDRS", []string{"localhost:11211"}),
+			},
 		},
 		HackerNews: HackerNewsConfig{
-			JinaKey:  getEnvOrDefault("JINA_KEY", ""),
-			MaxItems: getEnvIntOrDefault("MAX_ITEMS", 10),
+			Source:          getEnvOrDefault("HACKER_NEWS_SOURCE", "html"),
+			JinaKey:         getEnvOrDefault("JINA_KEY", ""),
+			MaxItems:        getEnvIntOrDefault("MAX_ITEMS", 10),
+			ArticleCacheDir: getEnvOrDefault("ARTICLE_CACHE_DIR", "./cache/articles"),
 		},
 		TTS: TTSConfig{
 			Provider: getEnvOrDefault("TTS_PROVIDER", "edge"),
@@ -112,6 +294,38 @@ func LoadConfig() *Config {
 				Region:          getEnvOrDefault("ALIYUN_REGION", "cn-shanghai"),
 				VoiceID:         getEnvOrDefault("ALIYUN_VOICE_ID", "xiaoyun"),
 			},
+			TencentTTS: TencentTTSConfig{
+				SecretId:            getEnvOrDefault("TENCENT_SECRET_ID", ""),
+				SecretKey:           getEnvOrDefault("TENCENT_SECRET_KEY", ""),
+				Region:              getEnvOrDefault("TENCENT_REGION", "ap-guangzhou"),
+				AppID:               getEnvOrDefault("TENCENT_APP_ID", ""),
+				VoiceID:             getEnvOrDefault("TENCENT_VOICE_ID", "101001"),
+				ModerationThreshold: getEnvFloatOrDefault("TENCENT_MODERATION_THRESHOLD", 0.8),
+			},
+			IFlytekTTS: IFlytekTTSConfig{
+				AppID:     getEnvOrDefault("IFLYTEK_APP_ID", ""),
+				APIKey:    getEnvOrDefault("IFLYTEK_API_KEY", ""),
+				APISecret: getEnvOrDefault("IFLYTEK_API_SECRET", ""),
+				VoiceName: getEnvOrDefault("IFLYTEK_VOICE_NAME", "xiaoyan"),
+			},
+		},
+		Feed: FeedConfig{
+			Title:       getEnvOrDefault("FEED_TITLE", "Hacker News 每日播客"),
+			Description: getEnvOrDefault("FEED_DESCRIPTION", "每天为你播报 Hacker News 热门文章"),
+			Link:        getEnvOrDefault("FEED_LINK", "https://news.ycombinator.com"),
+			Image:       getEnvOrDefault("FEED_IMAGE", ""),
+			Category:    getEnvOrDefault("FEED_CATEGORY", "Technology"),
+			Explicit:    getEnvOrDefault("FEED_EXPLICIT", "false") == "true",
+			OwnerEmail:  getEnvOrDefault("FEED_OWNER_EMAIL", ""),
+		},
+		Publish: PublishConfig{
+			Channels: getEnvListOrDefault("PUBLISH_CHANNELS", []string{}),
+			WeChat: WeChatPublishConfig{
+				Credentials: getEnvWeChatCredentialsOrDefault("PUBLISH_WECHAT_CREDENTIALS", nil),
+			},
+			DingTalk: DingTalkPublishConfig{
+				Bots: getEnvDingTalkBotsOrDefault("PUBLISH_DINGTALK_BOTS", nil),
+			},
 		},
 	}
 }
@@ -137,3 +351,91 @@ func getEnvIntOrDefault(key string, defaultValue int) int {
 	}
 	return intValue
 }
+
+// getEnvFloatOrDefault 获取环境变量(浮点数)或默认值
+func getEnvFloatOrDefault(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
+
+// getEnvDurationOrDefault 获取环境变量(时间段，如"24h"、"30m")或默认值
+func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvCredentialsOrDefault 获取环境变量(JSON数组，如`[{"name":"a","apiKey":"..."}]`)或默认值，
+// 用于配置ai.Client的多凭证路由
+func getEnvCredentialsOrDefault(key string, defaultValue []CredentialConfig) []CredentialConfig {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var credentials []CredentialConfig
+	if err := json.Unmarshal([]byte(value), &credentials); err != nil {
+		log.Printf("警告: 解析%s失败，忽略多凭证配置: %v", key, err)
+		return defaultValue
+	}
+	return credentials
+}
+
+// getEnvWeChatCredentialsOrDefault 获取环境变量(JSON数组)或默认值，
+// 用于配置多个微信公众号的发布凭证
+func getEnvWeChatCredentialsOrDefault(key string, defaultValue []WeChatCredential) []WeChatCredential {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var credentials []WeChatCredential
+	if err := json.Unmarshal([]byte(value), &credentials); err != nil {
+		log.Printf("警告: 解析%s失败，忽略微信公众号凭证配置: %v", key, err)
+		return defaultValue
+	}
+	return credentials
+}
+
+// getEnvDingTalkBotsOrDefault 获取环境变量(JSON数组)或默认值，
+// 用于配置多个钉钉机器人
+func getEnvDingTalkBotsOrDefault(key string, defaultValue []DingTalkBot) []DingTalkBot {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var bots []DingTalkBot
+	if err := json.Unmarshal([]byte(value), &bots); err != nil {
+		log.Printf("警告: 解析%s失败，忽略钉钉机器人配置: %v", key, err)
+		return defaultValue
+	}
+	return bots
+}
+
+// getEnvListOrDefault 获取环境变量(逗号分隔列表)或默认值
+func getEnvListOrDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -17,7 +17,7 @@ func main() {
 	cfg := config.LoadConfig()
 
 	// 创建MinIO客户端
-	minioClient, err := storage.NewMinioClient(&cfg.MinIO)
+	minioClient, err := storage.NewMinioClient(&cfg.Storage.MinIO)
 	if err != nil {
 		log.Fatalf("创建MinIO客户端失败: %v", err)
 	}
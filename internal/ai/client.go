@@ -2,45 +2,75 @@ package ai
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"hacker-news/config"
+	"hacker-news/internal/cache"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/sashabaranov/go-openai"
 )
 
-// Client 是AI接口的客户端
+// Client 是AI接口的客户端，内部维护一个凭证池，
+// 按权重轮询选择凭证并在额度耗尽或请求失败时自动故障转移到下一个凭证
 type Client struct {
-	client    *openai.Client
 	config    *config.OpenAIConfig
 	maxTokens int
-}
+	cache     cache.Cache
+	cacheTTL  time.Duration
 
-// NewClient 创建一个新的AI客户端
-func NewClient(cfg *config.OpenAIConfig) *Client {
-	// 使用提供的配置创建客户端
-	apiKey := cfg.APIKey
-	baseURL := cfg.BaseURL
+	credMu      sync.Mutex
+	credentials []*credentialState
+}
 
-	// 如果配置中没有提供API密钥，使用默认值
-	if apiKey == "" {
+// NewClient 创建一个新的AI客户端，c为nil时不启用缓存
+func NewClient(cfg *config.OpenAIConfig, c cache.Cache, cacheTTL time.Duration) *Client {
+	if len(cfg.Credentials) == 0 && cfg.APIKey == "" {
 		log.Println("警告: 未设置OPENAI_API_KEY环境变量，使用默认API密钥")
-		apiKey = cfg.DefaultAPIKey
 	}
 
-	// 创建OpenAI配置
-	clientConfig := openai.DefaultConfig(apiKey)
-	clientConfig.BaseURL = baseURL
+	return &Client{
+		config:      cfg,
+		maxTokens:   cfg.MaxTokens,
+		cache:       c,
+		cacheTTL:    cacheTTL,
+		credentials: buildCredentialStates(cfg),
+	}
+}
 
-	// 创建客户端
-	client := openai.NewClientWithConfig(clientConfig)
+// pickCredential 使用平滑加权轮询(SWRR)选择一个当日额度未耗尽的凭证，
+// 全部凭证额度耗尽时返回nil
+func (c *Client) pickCredential() *credentialState {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
 
-	return &Client{
-		client:    client,
-		config:    cfg,
-		maxTokens: cfg.MaxTokens,
+	var best *credentialState
+	totalWeight := 0
+	for _, cr := range c.credentials {
+		if isExhausted(cr.cfg) {
+			continue
+		}
+		cr.currentWeight += cr.weight()
+		totalWeight += cr.weight()
+		if best == nil || cr.currentWeight > best.currentWeight {
+			best = cr
+		}
 	}
+	if best == nil {
+		return nil
+	}
+	best.currentWeight -= totalWeight
+	return best
+}
+
+// cacheKey 以模型+提示词+输入内容的SHA-256摘要作为缓存键
+func (c *Client) cacheKey(prompt string, input string) string {
+	h := sha256.Sum256([]byte(c.config.Model + ":" + prompt + ":" + input))
+	return "ai:" + hex.EncodeToString(h[:])
 }
 
 // GenerateStoryText 生成单个文章的摘要
@@ -51,6 +81,14 @@ func (c *Client) GenerateStoryText(ctx context.Context, storyContent string) (st
 		storyContent = storyContent[:maxLength]
 	}
 
+	// 优先从缓存读取摘要
+	key := c.cacheKey(SummarizeStoryPrompt, storyContent)
+	if c.cache != nil {
+		if val, ok := c.cache.Get(ctx, key); ok {
+			return string(val), nil
+		}
+	}
+
 	// 创建聊天请求
 	req := openai.ChatCompletionRequest{
 		Model: c.config.Model,
@@ -68,13 +106,30 @@ func (c *Client) GenerateStoryText(ctx context.Context, storyContent string) (st
 	}
 
 	// 发送请求
-	return c.generateText(ctx, req)
+	result, err := c.generateText(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(ctx, key, []byte(result), c.cacheTTL)
+	}
+	return result, nil
 }
 
 // GeneratePodcastContent 生成播客内容
 func (c *Client) GeneratePodcastContent(ctx context.Context, summaries []string) (string, error) {
 	// 合并所有摘要
 	content := JoinContents(summaries)
+	prompt := SummarizePodcastPrompt()
+
+	// 优先从缓存读取播客内容
+	key := c.cacheKey(prompt, content)
+	if c.cache != nil {
+		if val, ok := c.cache.Get(ctx, key); ok {
+			return string(val), nil
+		}
+	}
 
 	// 创建聊天请求
 	req := openai.ChatCompletionRequest{
@@ -82,7 +137,7 @@ func (c *Client) GeneratePodcastContent(ctx context.Context, summaries []string)
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: SummarizePodcastPrompt(),
+				Content: prompt,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -93,7 +148,15 @@ func (c *Client) GeneratePodcastContent(ctx context.Context, summaries []string)
 	}
 
 	// 发送请求
-	return c.generateText(ctx, req)
+	result, err := c.generateText(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(ctx, key, []byte(result), c.cacheTTL)
+	}
+	return result, nil
 }
 
 // GenerateBlogContent 生成博客内容
@@ -143,23 +206,55 @@ func (c *Client) GenerateIntroContent(ctx context.Context, podcastContent string
 	return c.generateText(ctx, req)
 }
 
-// generateText 发送AI请求并获取生成的文本
+// generateText 发送AI请求并获取生成的文本。
+// 每次尝试都通过pickCredential选择一个凭证；遇到429/5xx/鉴权错误或额度耗尽时，
+// 自动故障转移到下一个凭证，而不是在同一个失效的凭证上反复重试
 func (c *Client) generateText(ctx context.Context, req openai.ChatCompletionRequest) (string, error) {
-	log.Printf("生成AI内容，模型: %s", req.Model)
-
-	// 添加重试逻辑
 	maxRetries := 3
+	var lastErr error
+
+	// SWRR在连续totalWeight次选择内会轮到每个未耗尽的凭证，
+	// 据此跳过本次调用中已经失败过的凭证
+	failed := make(map[*credentialState]bool)
+	totalWeight := 0
+	for _, cr := range c.credentials {
+		totalWeight += cr.weight()
+	}
+
 	for i := 0; i < maxRetries; i++ {
+		cred := c.pickCredential()
+		for n := 1; cred != nil && failed[cred] && n < totalWeight; n++ {
+			cred = c.pickCredential()
+		}
+		if cred == nil {
+			if lastErr != nil {
+				return "", fmt.Errorf("所有AI凭证当日额度均已用尽，最后一次错误: %w", lastErr)
+			}
+			return "", fmt.Errorf("所有AI凭证当日额度均已用尽")
+		}
+
+		credReq := req
+		if cred.cfg.Model != "" {
+			credReq.Model = cred.cfg.Model
+		}
+		log.Printf("生成AI内容，凭证: %s，模型: %s", cred.cfg.Name, credReq.Model)
+
 		// 添加超时
 		timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer cancel()
+		resp, err := cred.client.CreateChatCompletion(timeoutCtx, credReq)
+		cancel()
 
-		// 发送请求
-		resp, err := c.client.CreateChatCompletion(timeoutCtx, req)
 		if err != nil {
-			// 检查是否是可重试的错误
+			lastErr = err
+			failed[cred] = true
 			if i < maxRetries-1 {
-				log.Printf("AI请求失败，正在重试 (%d/%d): %v", i+1, maxRetries, err)
+				log.Printf("凭证%s请求失败，故障转移到下一个凭证 (%d/%d): %v", cred.cfg.Name, i+1, maxRetries, err)
+				// 只有在确实存在其他凭证可以切换时，才跳过退避直接换凭证；
+				// 只有一个凭证（常见于未配置Credentials的默认场景）时，
+				// 立即重试打的还是同一个上游，仍要退避等待（等待时长随重试次数递增）
+				if len(c.credentials) > 1 && shouldFailoverImmediately(err) {
+					continue
+				}
 				time.Sleep(time.Duration(i+1) * 2 * time.Second) // 指数退避
 				continue
 			}
@@ -168,19 +263,32 @@ func (c *Client) generateText(ctx context.Context, req openai.ChatCompletionRequ
 
 		// 检查响应是否有效
 		if len(resp.Choices) == 0 {
+			lastErr = fmt.Errorf("AI响应中没有内容")
 			if i < maxRetries-1 {
-				log.Printf("AI响应无效，正在重试 (%d/%d)", i+1, maxRetries)
+				log.Printf("凭证%s响应无效，正在重试 (%d/%d)", cred.cfg.Name, i+1, maxRetries)
 				time.Sleep(time.Duration(i+1) * 2 * time.Second)
 				continue
 			}
-			return "", fmt.Errorf("AI响应中没有内容")
+			return "", lastErr
 		}
 
-		log.Printf("AI内容生成成功，使用tokens: %d", resp.Usage.TotalTokens)
+		recordUsage(cred.cfg.Name, resp.Usage.TotalTokens)
+		log.Printf("AI内容生成成功，凭证: %s，使用tokens: %d", cred.cfg.Name, resp.Usage.TotalTokens)
 		return resp.Choices[0].Message.Content, nil
 	}
 
-	return "", fmt.Errorf("超过最大重试次数")
+	return "", fmt.Errorf("超过最大重试次数: %w", lastErr)
+}
+
+// shouldFailoverImmediately 判断是否应立即换用下一个凭证而不是退避重试当前凭证，
+// 覆盖429限流、5xx服务端错误和401/403鉴权失败
+func shouldFailoverImmediately(err error) bool {
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	code := apiErr.HTTPStatusCode
+	return code == 429 || code >= 500 || code == 401 || code == 403
 }
 
 // JoinContents 将多个内容合并为一个字符串，以分隔符分隔
@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"hacker-news/config"
+	"sync"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// credentialState 是单个AI凭证在Client内部的运行时状态，
+// currentWeight用于平滑加权轮询(SWRR)选择下一个凭证
+type credentialState struct {
+	cfg           config.CredentialConfig
+	client        *openai.Client
+	currentWeight int
+}
+
+// weight 返回该凭证参与轮询的权重，未配置时按1处理
+func (s *credentialState) weight() int {
+	if s.cfg.Weight <= 0 {
+		return 1
+	}
+	return s.cfg.Weight
+}
+
+// buildCredentialStates 根据配置构造凭证池。
+// 如果未配置Credentials，退化为由BaseURL/APIKey/Model组成的单个凭证，保持向后兼容
+func buildCredentialStates(cfg *config.OpenAIConfig) []*credentialState {
+	credConfigs := cfg.Credentials
+	if len(credConfigs) == 0 {
+		apiKey := cfg.APIKey
+		if apiKey == "" {
+			apiKey = cfg.DefaultAPIKey
+		}
+		credConfigs = []config.CredentialConfig{
+			{
+				Name:    "default",
+				BaseURL: cfg.BaseURL,
+				APIKey:  apiKey,
+				Model:   cfg.Model,
+			},
+		}
+	}
+
+	states := make([]*credentialState, 0, len(credConfigs))
+	for i, cc := range credConfigs {
+		if cc.Name == "" {
+			cc.Name = cc.Model
+		}
+		if cc.Name == "" {
+			cc.Name = "credential-" + string(rune('a'+i))
+		}
+
+		clientConfig := openai.DefaultConfig(cc.APIKey)
+		if cc.BaseURL != "" {
+			clientConfig.BaseURL = cc.BaseURL
+		}
+
+		states = append(states, &credentialState{
+			cfg:    cc,
+			client: openai.NewClientWithConfig(clientConfig),
+		})
+		registerCredential(cc)
+	}
+	return states
+}
+
+// dailyUsage 记录单个凭证当天消耗的token数，按日期自动重置
+type dailyUsage struct {
+	mu     sync.Mutex
+	date   string
+	tokens int
+}
+
+func (u *dailyUsage) add(tokens int) {
+	today := time.Now().Format("2006-01-02")
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.date != today {
+		u.date = today
+		u.tokens = 0
+	}
+	u.tokens += tokens
+}
+
+func (u *dailyUsage) get() (tokensToday int) {
+	today := time.Now().Format("2006-01-02")
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.date != today {
+		return 0
+	}
+	return u.tokens
+}
+
+// CredentialStat 是单个AI凭证当天的用量快照，供运维排查配额问题
+type CredentialStat struct {
+	Name             string
+	Model            string
+	DailyTokenBudget int
+	UsedTokensToday  int
+	Exhausted        bool
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]config.CredentialConfig{}
+	usageMu    sync.Mutex
+	usage      = map[string]*dailyUsage{}
+)
+
+// registerCredential 把凭证登记到全局注册表，供Stats()枚举
+func registerCredential(cc config.CredentialConfig) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[cc.Name] = cc
+}
+
+// recordUsage 累加某个凭证当天消耗的token数
+func recordUsage(name string, tokens int) {
+	usageMu.Lock()
+	u, ok := usage[name]
+	if !ok {
+		u = &dailyUsage{}
+		usage[name] = u
+	}
+	usageMu.Unlock()
+	u.add(tokens)
+}
+
+// usedTokensToday 返回某个凭证当天已消耗的token数
+func usedTokensToday(name string) int {
+	usageMu.Lock()
+	u, ok := usage[name]
+	usageMu.Unlock()
+	if !ok {
+		return 0
+	}
+	return u.get()
+}
+
+// isExhausted 判断某个凭证当天的用量是否已达到预算上限
+func isExhausted(cc config.CredentialConfig) bool {
+	if cc.DailyTokenBudget <= 0 {
+		return false
+	}
+	return usedTokensToday(cc.Name) >= cc.DailyTokenBudget
+}
+
+// Stats 返回所有已注册AI凭证当天的用量快照，供运维查看配额消耗情况
+func Stats() []CredentialStat {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	stats := make([]CredentialStat, 0, len(registry))
+	for _, cc := range registry {
+		stats = append(stats, CredentialStat{
+			Name:             cc.Name,
+			Model:            cc.Model,
+			DailyTokenBudget: cc.DailyTokenBudget,
+			UsedTokensToday:  usedTokensToday(cc.Name),
+			Exhausted:        isExhausted(cc),
+		})
+	}
+	return stats
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// concatAudioHandler 合并多个音频片段，支持插入静音间隔，
+// 输出重新上传到对象存储并返回预签名URL与计算出的时长
+func (s *Server) concatAudioHandler(c *gin.Context) {
+	var req struct {
+		AudioURLs  []string `json:"audioUrls" binding:"required"`
+		OutputName string   `json:"outputName"`
+		GapMs      int      `json:"gapMs"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "无效的请求参数",
+		})
+		return
+	}
+	if len(req.AudioURLs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "audioUrls不能为空",
+		})
+		return
+	}
+	if req.OutputName == "" {
+		req.OutputName = fmt.Sprintf("concat-%s", time.Now().Format("20060102-150405"))
+	}
+
+	ctx := c.Request.Context()
+
+	// 下载所有片段
+	var segments [][]byte
+	for _, url := range req.AudioURLs {
+		data, err := s.downloadAudioInput(ctx, url)
+		if err != nil {
+			log.Printf("下载音频片段 %s 失败: %v", url, err)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("下载音频片段失败: %v", err),
+			})
+			return
+		}
+		segments = append(segments, data)
+	}
+
+	// 通过工作队列限制并发ffmpeg任务数量
+	s.concatSem <- struct{}{}
+	defer func() { <-s.concatSem }()
+
+	merged, durationMs, err := mergeAudioBytesWithGap(ctx, segments, req.GapMs)
+	if err != nil {
+		log.Printf("合并音频失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "合并音频失败",
+		})
+		return
+	}
+
+	objectKey := fmt.Sprintf("audio/%s.mp3", req.OutputName)
+	audioURL, err := s.storageClient.UploadFile(ctx, objectKey, merged, "audio/mpeg")
+	if err != nil {
+		log.Printf("上传合并音频失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "上传合并音频失败",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"objectKey":  objectKey,
+		"audioUrl":   audioURL,
+		"durationMs": durationMs,
+	})
+}
+
+// downloadAudioInput 下载一个音频片段，支持完整的预签名URL或对象存储的对象键
+func (s *Server) downloadAudioInput(ctx context.Context, urlOrKey string) ([]byte, error) {
+	if strings.HasPrefix(urlOrKey, "http://") || strings.HasPrefix(urlOrKey, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlOrKey, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("下载音频返回状态码: %d", resp.StatusCode)
+		}
+
+		return io.ReadAll(resp.Body)
+	}
+
+	return s.storageClient.DownloadFile(ctx, urlOrKey)
+}
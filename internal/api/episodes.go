@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// episodeListItem 是归档列表接口返回的单条记录
+type episodeListItem struct {
+	Date     string `json:"date"`
+	Title    string `json:"title"`
+	Intro    string `json:"intro"`
+	AudioURL string `json:"audioUrl"`
+	HasAudio bool   `json:"hasAudio"`
+	Duration int    `json:"duration"`
+}
+
+// episodesPaging 分页信息
+type episodesPaging struct {
+	Total        int `json:"total"`
+	PageSize     int `json:"pageSize"`
+	CurrentIndex int `json:"currentIndex"`
+	TotalPage    int `json:"totalPage"`
+}
+
+// getEpisodesHandler 分页列出历史播客/博客归档
+func (s *Server) getEpisodesHandler(c *gin.Context) {
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	currentIndex, _ := strconv.Atoi(c.Query("currentIndex"))
+	if currentIndex <= 0 {
+		currentIndex = 1
+	}
+	startDate := c.Query("startDate")
+	endDate := c.Query("endDate")
+	keyword := c.Query("keyWord")
+	sortOrder := c.DefaultQuery("sort", "desc")
+
+	ctx := c.Request.Context()
+	env := s.config.Server.Env
+	prefix := "content:" + env + ":hacker-news:"
+
+	keys, err := s.storageClient.ListFiles(ctx, prefix)
+	if err != nil {
+		log.Printf("列出归档内容失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "获取归档列表失败",
+		})
+		return
+	}
+
+	var items []episodeListItem
+	for _, key := range keys {
+		date := strings.TrimPrefix(key, prefix)
+		if date == "" {
+			continue
+		}
+		if startDate != "" && date < startDate {
+			continue
+		}
+		if endDate != "" && date > endDate {
+			continue
+		}
+
+		contentData, err := s.storageClient.DownloadFile(ctx, key)
+		if err != nil {
+			log.Printf("获取 %s 的归档内容失败: %v", date, err)
+			continue
+		}
+
+		var content episodeContent
+		if err := json.Unmarshal(contentData, &content); err != nil {
+			log.Printf("解析 %s 的归档内容失败: %v", date, err)
+			continue
+		}
+
+		if keyword != "" && !containsAny(keyword, content.Intro, content.Blog, content.Podcast) {
+			continue
+		}
+
+		items = append(items, episodeListItem{
+			Date:     date,
+			Title:    "Hacker News 每日播客 " + date,
+			Intro:    content.Intro,
+			AudioURL: content.AudioURL,
+			HasAudio: content.AudioURL != "",
+			Duration: content.Duration,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if sortOrder == "asc" {
+			return items[i].Date < items[j].Date
+		}
+		return items[i].Date > items[j].Date
+	})
+
+	total := len(items)
+	totalPage := (total + pageSize - 1) / pageSize
+	start := (currentIndex - 1) * pageSize
+	end := start + pageSize
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	pageItems := items[start:end]
+
+	// 预签名URL惰性生成，只针对当前页
+	for i := range pageItems {
+		if pageItems[i].AudioURL == "" {
+			continue
+		}
+		audioKey := "audio/" + pageItems[i].Date + "-complete.mp3"
+		if url, err := s.storageClient.GetPresignedURL(ctx, audioKey, 24*time.Hour); err == nil {
+			pageItems[i].AudioURL = url
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"list": pageItems,
+		"paging": episodesPaging{
+			Total:        total,
+			PageSize:     pageSize,
+			CurrentIndex: currentIndex,
+			TotalPage:    totalPage,
+		},
+	})
+}
+
+// containsAny 检查keyword是否作为子串出现在任意一个候选字符串中（不区分大小写）
+func containsAny(keyword string, candidates ...string) bool {
+	keyword = strings.ToLower(keyword)
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate), keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportManifestEntry 描述导出ZIP中manifest.json里的一条记录
+type exportManifestEntry struct {
+	Date     string `json:"date"`
+	HasAudio bool   `json:"hasAudio"`
+	HasBlog  bool   `json:"hasBlog"`
+	HasIntro bool   `json:"hasIntro"`
+	Warning  string `json:"warning,omitempty"`
+}
+
+// exportHandler 把指定日期区间的内容打包为ZIP流式下载
+func (s *Server) exportHandler(c *gin.Context) {
+	if format := c.DefaultQuery("format", "zip"); format != "zip" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "仅支持 format=zip",
+		})
+		return
+	}
+
+	startDate := c.Query("startDate")
+	endDate := c.Query("endDate")
+	if startDate == "" || endDate == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "startDate和endDate不能为空",
+		})
+		return
+	}
+
+	include := strings.Split(c.DefaultQuery("include", "audio,blog,intro"), ",")
+	includeSet := make(map[string]bool)
+	for _, item := range include {
+		includeSet[strings.TrimSpace(item)] = true
+	}
+
+	maxDays, err := strconv.Atoi(c.DefaultQuery("maxDays", "31"))
+	if err != nil || maxDays <= 0 {
+		maxDays = 31
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "startDate格式无效",
+		})
+		return
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "endDate格式无效",
+		})
+		return
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	if days <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "endDate不能早于startDate",
+		})
+		return
+	}
+	if days > maxDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("导出范围不能超过 %d 天", maxDays),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("hacker-news-%s_%s.zip", startDate, endDate)
+	c.Writer.Header().Set("Content-Type", "application/zip")
+	c.Writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	ctx := c.Request.Context()
+	env := s.config.Server.Env
+
+	zipWriter := zip.NewWriter(c.Writer)
+	defer zipWriter.Close()
+
+	var manifest []exportManifestEntry
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		entry := exportManifestEntry{Date: date}
+
+		contentKey := "content:" + env + ":hacker-news:" + date
+		contentData, err := s.storageClient.DownloadFile(ctx, contentKey)
+		if err != nil {
+			entry.Warning = "内容不存在，已跳过"
+			manifest = append(manifest, entry)
+			continue
+		}
+
+		var content episodeContent
+		if err := json.Unmarshal(contentData, &content); err != nil {
+			entry.Warning = "内容解析失败，已跳过"
+			manifest = append(manifest, entry)
+			continue
+		}
+
+		if includeSet["audio"] {
+			audioKey := fmt.Sprintf("audio/%s-complete.mp3", date)
+			if audioData, err := s.storageClient.DownloadFile(ctx, audioKey); err == nil {
+				if err := writeZipEntry(zipWriter, date+"/podcast.mp3", audioData); err == nil {
+					entry.HasAudio = true
+				}
+			}
+		}
+		if includeSet["blog"] && content.Blog != "" {
+			if err := writeZipEntry(zipWriter, date+"/blog.md", []byte(content.Blog)); err == nil {
+				entry.HasBlog = true
+			}
+		}
+		if includeSet["intro"] && content.Intro != "" {
+			if err := writeZipEntry(zipWriter, date+"/intro.txt", []byte(content.Intro)); err == nil {
+				entry.HasIntro = true
+			}
+		}
+
+		manifest = append(manifest, entry)
+	}
+
+	manifestData, err := json.MarshalIndent(gin.H{
+		"startDate": startDate,
+		"endDate":   endDate,
+		"episodes":  manifest,
+	}, "", "  ")
+	if err != nil {
+		log.Printf("序列化导出manifest失败: %v", err)
+		return
+	}
+	if err := writeZipEntry(zipWriter, "manifest.json", manifestData); err != nil {
+		log.Printf("写入manifest.json失败: %v", err)
+	}
+}
+
+// writeZipEntry 把一段字节写入ZIP流中的一个条目
+func writeZipEntry(zipWriter *zip.Writer, name string, data []byte) error {
+	w, err := zipWriter.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, strings.NewReader(string(data)))
+	return err
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kgiannakakis/mp3duration/src/mp3duration"
+)
+
+// rssFeed 是播客RSS 2.0订阅源的根元素，带有iTunes和content命名空间
+type rssFeed struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XMLNSItunes  string     `xml:"xmlns:itunes,attr"`
+	XMLNSContent string     `xml:"xmlns:content,attr"`
+	Channel      rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title          string        `xml:"title"`
+	Link           string        `xml:"link"`
+	Description    string        `xml:"description"`
+	Language       string        `xml:"language"`
+	ItunesImage    *rssItunesImg `xml:"itunes:image"`
+	ItunesCategory *rssCategory  `xml:"itunes:category"`
+	ItunesExplicit string        `xml:"itunes:explicit"`
+	Items          []rssItem     `xml:"item"`
+}
+
+type rssItunesImg struct {
+	Href string `xml:"href,attr"`
+}
+
+type rssCategory struct {
+	Text string `xml:"text,attr"`
+}
+
+type rssItem struct {
+	Title          string       `xml:"title"`
+	PubDate        string       `xml:"pubDate"`
+	Description    string       `xml:"description"`
+	ContentEncoded string       `xml:"content:encoded"`
+	Enclosure      rssEnclosure `xml:"enclosure"`
+	GUID           string       `xml:"guid"`
+	ItunesDuration string       `xml:"itunes:duration"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// feedHandler 生成符合iTunes规范的播客RSS订阅源
+func (s *Server) feedHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	env := s.config.Server.Env
+	prefix := fmt.Sprintf("content:%s:hacker-news:", env)
+
+	keys, err := s.storageClient.ListFiles(ctx, prefix)
+	if err != nil {
+		log.Printf("列出播客内容失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "列出播客内容失败",
+		})
+		return
+	}
+
+	// 日期从对象名中提取，按日期倒序排列（最新的在前）
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	feed := rssFeed{
+		Version:      "2.0",
+		XMLNSItunes:  "http://www.itunes.com/dtds/podcast-1.0.dtd",
+		XMLNSContent: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:          s.config.Feed.Title,
+			Link:           s.config.Feed.Link,
+			Description:    s.config.Feed.Description,
+			Language:       "zh-cn",
+			ItunesExplicit: fmt.Sprintf("%t", s.config.Feed.Explicit),
+		},
+	}
+	if s.config.Feed.Image != "" {
+		feed.Channel.ItunesImage = &rssItunesImg{Href: s.config.Feed.Image}
+	}
+	if s.config.Feed.Category != "" {
+		feed.Channel.ItunesCategory = &rssCategory{Text: s.config.Feed.Category}
+	}
+
+	for _, key := range keys {
+		date := strings.TrimPrefix(key, prefix)
+		if date == "" {
+			continue
+		}
+
+		contentData, err := s.storageClient.DownloadFile(ctx, key)
+		if err != nil {
+			log.Printf("获取 %s 的播客内容失败: %v", date, err)
+			continue
+		}
+
+		var content episodeContent
+		if err := json.Unmarshal(contentData, &content); err != nil {
+			log.Printf("解析 %s 的播客内容失败: %v", date, err)
+			continue
+		}
+		if content.AudioURL == "" {
+			continue
+		}
+
+		mergedAudioKey := fmt.Sprintf("audio/%s-complete.mp3", date)
+
+		if content.Duration == 0 {
+			if dur, err := s.computeAndCacheDuration(ctx, key, mergedAudioKey, &content); err != nil {
+				log.Printf("计算 %s 的音频时长失败: %v", date, err)
+			} else {
+				content.Duration = dur
+			}
+		}
+
+		presignedURL, err := s.storageClient.GetPresignedURL(ctx, mergedAudioKey, 24*time.Hour)
+		if err != nil {
+			log.Printf("生成 %s 的预签名URL失败: %v", date, err)
+			continue
+		}
+
+		audioSize, err := s.storageClient.StatFile(ctx, mergedAudioKey)
+		if err != nil {
+			log.Printf("获取 %s 的音频文件大小失败: %v", date, err)
+			continue
+		}
+
+		pubDate, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			pubDate = time.Now()
+		}
+
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:          "Hacker News 每日播客 " + date,
+			PubDate:        pubDate.Format(time.RFC1123Z),
+			Description:    content.Intro,
+			ContentEncoded: content.Blog,
+			GUID:           "hacker-news-" + date,
+			ItunesDuration: formatItunesDuration(content.Duration),
+			Enclosure: rssEnclosure{
+				URL:    presignedURL,
+				Length: fmt.Sprintf("%d", audioSize),
+				Type:   "audio/mpeg",
+			},
+		})
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		log.Printf("序列化RSS订阅源失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "生成RSS订阅源失败",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/rss+xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+// computeAndCacheDuration 下载音频文件计算真实MP3时长（秒），并把结果写回内容对象缓存起来
+func (s *Server) computeAndCacheDuration(ctx context.Context, contentKey string, audioKey string, content *episodeContent) (int, error) {
+	audioData, err := s.storageClient.DownloadFile(ctx, audioKey)
+	if err != nil {
+		return 0, fmt.Errorf("下载音频失败: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp("", "duration-*.mp3")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if _, err := tempFile.Write(audioData); err != nil {
+		return 0, err
+	}
+
+	duration, err := mp3duration.Calculate(tempFile.Name())
+	if err != nil {
+		return 0, fmt.Errorf("计算MP3时长失败: %w", err)
+	}
+
+	content.Duration = int(duration)
+
+	contentData, err := json.Marshal(content)
+	if err != nil {
+		return content.Duration, fmt.Errorf("序列化内容失败: %w", err)
+	}
+	if _, err := s.storageClient.UploadFile(ctx, contentKey, contentData, "application/json"); err != nil {
+		return content.Duration, fmt.Errorf("缓存音频时长失败: %w", err)
+	}
+
+	return content.Duration, nil
+}
+
+// formatItunesDuration 把秒数格式化为iTunes要求的HH:MM:SS
+func formatItunesDuration(totalSeconds int) string {
+	hours := totalSeconds / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
@@ -3,15 +3,20 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hacker-news/config"
 	"hacker-news/internal/ai"
+	"hacker-news/internal/audio"
+	"hacker-news/internal/cache"
 	"hacker-news/internal/crawler"
 	"hacker-news/internal/storage"
 	"hacker-news/internal/tts"
 	"log"
+	"mime"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +31,9 @@ import (
 	// "io/ioutil"
 	"os/exec"
 	"path/filepath"
+	"sync"
+
+	"github.com/kgiannakakis/mp3duration/src/mp3duration"
 )
 
 // Server 是API服务器结构
@@ -33,29 +41,52 @@ type Server struct {
 	config        *config.Config
 	router        *gin.Engine
 	aiClient      *ai.Client
-	minioClient   *storage.MinioClient
-	hnClient      *crawler.HackerNewsClient
+	storageClient storage.Backend
+	hnClient      crawler.NewsSource
 	ttsService    tts.Service
 	isProcessing  bool
 	lastProcessed time.Time
+
+	hlsMu    sync.Mutex
+	hlsReady map[string]bool // 已生成HLS切片的日期缓存
+
+	concatSem chan struct{} // 限制并发ffmpeg合并任务的工作队列
+}
+
+// episodeContent 是存储在对象存储中的每日内容对象的结构，
+// 同时被播客/博客详情、RSS订阅源和归档列表接口复用
+type episodeContent struct {
+	Intro      string   `json:"intro"`
+	Podcast    string   `json:"podcast"`
+	Blog       string   `json:"blog"`
+	AudioURL   string   `json:"audioUrl"`
+	AudioFiles []string `json:"audioFiles,omitempty"`
+	Duration   int      `json:"duration,omitempty"` // 音频时长（秒），惰性计算后缓存
 }
 
 // NewServer 创建一个新的API服务器
 func NewServer(cfg *config.Config) (*Server, error) {
+	// 创建缓存后端（内存LRU/Redis/Memcached，由cfg.Cache.Provider决定），
+	// 供AI摘要、抓取的文章和TTS音频复用以避免重复调用昂贵的外部服务
+	cacheClient, err := cache.Factory(&cfg.Cache)
+	if err != nil {
+		return nil, err
+	}
+
 	// 创建AI客户端
-	aiClient := ai.NewClient(&cfg.OpenAI)
+	aiClient := ai.NewClient(&cfg.OpenAI, cacheClient, cfg.Cache.DefaultTTL)
 
-	// 创建MinIO客户端
-	minioClient, err := storage.NewMinioClient(&cfg.MinIO)
+	// 创建对象存储后端（MinIO/S3/OSS/本地文件系统，由cfg.Storage.Provider决定）
+	storageClient, err := storage.Factory(&cfg.Storage)
 	if err != nil {
 		return nil, err
 	}
 
 	// 创建Hacker News客户端
-	hnClient := crawler.NewHackerNewsClient(cfg.HackerNews.JinaKey)
+	hnClient := crawler.NewNewsSource(&cfg.HackerNews, cacheClient, cfg.Cache.DefaultTTL)
 
 	// 创建TTS服务
-	ttsService, err := tts.Factory(&cfg.TTS)
+	ttsService, err := tts.Factory(&cfg.TTS, cacheClient, cfg.Cache.DefaultTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +114,13 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		config:        cfg,
 		router:        router,
 		aiClient:      aiClient,
-		minioClient:   minioClient,
+		storageClient: storageClient,
 		hnClient:      hnClient,
 		ttsService:    ttsService,
 		isProcessing:  false,
 		lastProcessed: time.Time{},
+		hlsReady:      make(map[string]bool),
+		concatSem:     make(chan struct{}, 2),
 	}
 
 	// 注册路由
@@ -121,13 +154,32 @@ func (s *Server) registerRoutes() {
 
 		// 音频合并
 		v1.POST("/audio/concat", s.concatAudioHandler)
-		
+
 		// 删除内容
 		v1.DELETE("/content", s.deleteContentHandler)
+
+		// 播客RSS订阅源
+		v1.GET("/feed.rss", s.feedHandler)
+
+		// 分页归档列表
+		v1.GET("/episodes", s.getEpisodesHandler)
+
+		// 按日期区间导出ZIP归档
+		v1.GET("/export", s.exportHandler)
+
+		// AI凭证用量统计
+		v1.GET("/ai/stats", s.getAIStatsHandler)
 	}
 
 	// 提供音频文件
 	s.router.GET("/audio/:filename", s.serveAudioHandler)
+
+	// HLS自适应流
+	s.router.GET("/hls/:date/playlist.m3u8", s.hlsPlaylistHandler)
+	s.router.GET("/hls/:date/:segment", s.hlsSegmentHandler)
+
+	// 本地文件系统存储后端的签名URL回源（storage.Provider=local时才会被真正用到）
+	s.router.GET("/storage/*objectName", s.serveLocalStorageHandler)
 }
 
 // Run 启动API服务器
@@ -154,7 +206,7 @@ func (s *Server) processHandler(c *gin.Context) {
 	var req struct {
 		Date       string `json:"date"`
 		MaxItems   int    `json:"maxItems"`
-		Force      bool   `json:"force"`      // 强制重新处理内容
+		Force      bool   `json:"force"`       // 强制重新处理内容
 		ForceAudio bool   `json:"force_audio"` // 强制重新生成音频
 	}
 
@@ -207,7 +259,7 @@ func (s *Server) getPodcastHandler(c *gin.Context) {
 
 	// 获取预签名URL
 	ctx := c.Request.Context()
-	presignedURL, err := s.minioClient.GetPresignedURL(ctx, podcastObjectName, 24*time.Hour)
+	presignedURL, err := s.storageClient.GetPresignedURL(ctx, podcastObjectName, 24*time.Hour)
 	if err != nil {
 		log.Printf("获取预签名URL失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -218,7 +270,7 @@ func (s *Server) getPodcastHandler(c *gin.Context) {
 
 	// 获取内容
 	contentObjectName := "content:" + env + ":hacker-news:" + date
-	contentData, err := s.minioClient.DownloadFile(ctx, contentObjectName)
+	contentData, err := s.storageClient.DownloadFile(ctx, contentObjectName)
 	if err != nil {
 		log.Printf("获取内容失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -227,12 +279,7 @@ func (s *Server) getPodcastHandler(c *gin.Context) {
 		return
 	}
 
-	var content struct {
-		Intro    string `json:"intro"`
-		Podcast  string `json:"podcast"`
-		Blog     string `json:"blog"`
-		AudioURL string `json:"audioUrl"`
-	}
+	var content episodeContent
 
 	// 解析内容
 	if err := json.Unmarshal(contentData, &content); err != nil {
@@ -267,7 +314,7 @@ func (s *Server) getBlogHandler(c *gin.Context) {
 
 	// 获取内容
 	ctx := c.Request.Context()
-	contentData, err := s.minioClient.DownloadFile(ctx, contentObjectName)
+	contentData, err := s.storageClient.DownloadFile(ctx, contentObjectName)
 	if err != nil {
 		log.Printf("获取内容失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -276,12 +323,7 @@ func (s *Server) getBlogHandler(c *gin.Context) {
 		return
 	}
 
-	var content struct {
-		Intro    string `json:"intro"`
-		Podcast  string `json:"podcast"`
-		Blog     string `json:"blog"`
-		AudioURL string `json:"audioUrl"`
-	}
+	var content episodeContent
 
 	// 解析内容
 	if err := json.Unmarshal(contentData, &content); err != nil {
@@ -308,6 +350,13 @@ func (s *Server) getStatusHandler(c *gin.Context) {
 	})
 }
 
+// getAIStatsHandler 返回各AI凭证当天的用量统计，供运维排查配额问题
+func (s *Server) getAIStatsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": ai.Stats(),
+	})
+}
+
 // ttsHandler 文本转语音处理
 func (s *Server) ttsHandler(c *gin.Context) {
 	// 获取请求参数
@@ -325,7 +374,7 @@ func (s *Server) ttsHandler(c *gin.Context) {
 
 	// 转换文本为语音
 	ctx := c.Request.Context()
-	audio, err := s.ttsService.SynthesizeSpeech(ctx, req.Text, req.Speaker)
+	audio, err := s.ttsService.Synthesize(ctx, tts.SynthesisRequest{Text: req.Text, Speaker: req.Speaker})
 	if err != nil {
 		log.Printf("文本转语音失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -337,9 +386,9 @@ func (s *Server) ttsHandler(c *gin.Context) {
 	// 生成文件名
 	filename := "tts-" + time.Now().Format("20060102-150405") + ".mp3"
 
-	// 上传到MinIO
+	// 上传到对象存储
 	contentType := "audio/mpeg"
-	audioURL, err := s.minioClient.UploadFile(ctx, filename, audio, contentType)
+	audioURL, err := s.storageClient.UploadFile(ctx, filename, audio, contentType)
 	if err != nil {
 		log.Printf("上传音频失败: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -354,24 +403,24 @@ func (s *Server) ttsHandler(c *gin.Context) {
 	})
 }
 
-// concatAudioHandler 合并音频处理
-func (s *Server) concatAudioHandler(c *gin.Context) {
-	// 获取请求参数
-	var req struct {
-		AudioURLs []string `json:"audioUrls" binding:"required"`
+// passesModeration 在发布前审核合成音频，仅当当前TTS服务支持Moderate能力时才生效；
+// 审核失败（网络错误等）按不阻塞发布处理，只有明确检测到违规才拒绝
+func (s *Server) passesModeration(ctx context.Context, audio []byte) bool {
+	moderator, ok := s.ttsService.(tts.Moderator)
+	if !ok {
+		return true
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "无效的请求参数",
-		})
-		return
+	result, err := moderator.Moderate(ctx, audio)
+	if err != nil {
+		log.Printf("语音审核失败，跳过审核放行: %v", err)
+		return true
 	}
-
-	// 这里简单演示，实际合并逻辑需要使用FFmpeg或其他音频处理库
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "音频合并功能尚未实现",
-	})
+	if result.Flagged {
+		log.Printf("语音审核未通过: %+v", result.Categories)
+		return false
+	}
+	return true
 }
 
 // serveAudioHandler 提供音频文件
@@ -388,7 +437,7 @@ func (s *Server) serveAudioHandler(c *gin.Context) {
 	// 使用统一的路径方式：audio/文件名
 	audioPath := "audio/" + filename
 	ctx := c.Request.Context()
-	data, err := s.minioClient.DownloadFile(ctx, audioPath)
+	data, err := s.storageClient.DownloadFile(ctx, audioPath)
 	if err != nil {
 		log.Printf("获取音频文件失败: %v", err)
 		c.JSON(http.StatusNotFound, gin.H{
@@ -403,6 +452,191 @@ func (s *Server) serveAudioHandler(c *gin.Context) {
 	c.Writer.Write(data)
 }
 
+// serveLocalStorageHandler 校验本地文件系统存储后端签发的签名URL，校验通过后回源文件内容。
+// 只有storage.Provider=local时storageClient才是*storage.LocalClient，其余情况一律404
+func (s *Server) serveLocalStorageHandler(c *gin.Context) {
+	localClient, ok := s.storageClient.(*storage.LocalClient)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "当前存储后端不支持此路由",
+		})
+		return
+	}
+
+	objectName := strings.TrimPrefix(c.Param("objectName"), "/")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil || !localClient.VerifySignedURL(objectName, expires, c.Query("sig")) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "签名无效或已过期",
+		})
+		return
+	}
+
+	data, err := localClient.DownloadFile(c.Request.Context(), objectName)
+	if err != nil {
+		log.Printf("读取本地存储文件失败: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "文件不存在",
+		})
+		return
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(objectName))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Writer.Header().Set("Content-Type", contentType)
+	c.Writer.Write(data)
+}
+
+// hlsPlaylistHandler 提供指定日期播客的HLS播放列表
+func (s *Server) hlsPlaylistHandler(c *gin.Context) {
+	date := c.Param("date")
+	if date == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "日期不能为空",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := s.ensureHLSStream(ctx, date); err != nil {
+		log.Printf("生成HLS切片失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "生成HLS播放列表失败",
+		})
+		return
+	}
+
+	data, err := s.storageClient.DownloadFile(ctx, hlsPlaylistKey(date))
+	if err != nil {
+		log.Printf("获取HLS播放列表失败: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "播放列表不存在",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/vnd.apple.mpegurl", data)
+}
+
+// hlsSegmentHandler 提供HLS的单个TS切片
+func (s *Server) hlsSegmentHandler(c *gin.Context) {
+	date := c.Param("date")
+	segment := c.Param("segment")
+	if date == "" || segment == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "参数不能为空",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	data, err := s.storageClient.DownloadFile(ctx, hlsSegmentKey(date, segment))
+	if err != nil {
+		log.Printf("获取HLS切片失败: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "切片不存在",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "video/MP2T", data)
+}
+
+// ensureHLSStream 确保指定日期的播客已经转码为HLS切片，首次请求时触发转码
+func (s *Server) ensureHLSStream(ctx context.Context, date string) error {
+	s.hlsMu.Lock()
+	if s.hlsReady[date] {
+		s.hlsMu.Unlock()
+		return nil
+	}
+	s.hlsMu.Unlock()
+
+	// 已经在对象存储中生成过，直接复用
+	exists, err := s.storageClient.ObjectExists(ctx, hlsPlaylistKey(date))
+	if err == nil && exists {
+		s.hlsMu.Lock()
+		s.hlsReady[date] = true
+		s.hlsMu.Unlock()
+		return nil
+	}
+
+	mergedAudioKey := fmt.Sprintf("audio/%s-complete.mp3", date)
+	mp3Data, err := s.storageClient.DownloadFile(ctx, mergedAudioKey)
+	if err != nil {
+		return fmt.Errorf("下载合并音频失败: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "hls-transcode")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	inPath := filepath.Join(tempDir, "in.mp3")
+	if err := os.WriteFile(inPath, mp3Data, 0644); err != nil {
+		return err
+	}
+
+	playlistPath := filepath.Join(tempDir, "out.m3u8")
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", inPath,
+		"-c:a", "aac", "-f", "hls", "-hls_time", "10",
+		"-hls_segment_type", "mpegts", "-hls_list_size", "0",
+		playlistPath)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg转码HLS失败: %w", err)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return err
+	}
+
+	// 上传切片并重写播放列表里的URI，使其指向新的路由
+	playlistData, err := os.ReadFile(playlistPath)
+	if err != nil {
+		return err
+	}
+	rewritten := string(playlistData)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".ts") {
+			continue
+		}
+		segData, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			return fmt.Errorf("读取切片 %s 失败: %w", name, err)
+		}
+		if _, err := s.storageClient.UploadFile(ctx, hlsSegmentKey(date, name), segData, "video/MP2T"); err != nil {
+			return fmt.Errorf("上传切片 %s 失败: %w", name, err)
+		}
+		rewritten = strings.ReplaceAll(rewritten, name, fmt.Sprintf("/hls/%s/%s", date, name))
+	}
+
+	if _, err := s.storageClient.UploadFile(ctx, hlsPlaylistKey(date), []byte(rewritten), "application/vnd.apple.mpegurl"); err != nil {
+		return fmt.Errorf("上传播放列表失败: %w", err)
+	}
+
+	s.hlsMu.Lock()
+	s.hlsReady[date] = true
+	s.hlsMu.Unlock()
+
+	log.Printf("日期 %s 的HLS切片生成完成", date)
+	return nil
+}
+
+// hlsPlaylistKey 返回播放列表在对象存储中的对象名
+func hlsPlaylistKey(date string) string {
+	return fmt.Sprintf("hls/%s/playlist.m3u8", date)
+}
+
+// hlsSegmentKey 返回切片在对象存储中的对象名
+func hlsSegmentKey(date string, segment string) string {
+	return fmt.Sprintf("hls/%s/%s", date, segment)
+}
+
 // deleteContentHandler 删除已有内容
 func (s *Server) deleteContentHandler(c *gin.Context) {
 	// 获取日期参数
@@ -417,30 +651,30 @@ func (s *Server) deleteContentHandler(c *gin.Context) {
 	// 构建内容对象名
 	env := s.config.Server.Env
 	contentObjectName := "content:" + env + ":hacker-news:" + date
-	
+
 	// 构建音频对象名(新格式)
 	audioObjectName := "audio/hacker-news-" + date + ".mp3"
-	
+
 	// 构建音频对象名(旧格式)
 	oldAudioObjectName := strings.ReplaceAll(date, "-", "/") + "/" + env + "/hacker-news-" + date + ".mp3"
 
 	// 删除内容
 	ctx := c.Request.Context()
-	err := s.minioClient.DeleteFile(ctx, contentObjectName)
+	err := s.storageClient.DeleteFile(ctx, contentObjectName)
 	if err != nil {
 		log.Printf("删除内容失败: %v", err)
 		// 继续执行，因为有可能内容不存在
 	}
 
 	// 尝试删除新格式音频
-	err = s.minioClient.DeleteFile(ctx, audioObjectName)
+	err = s.storageClient.DeleteFile(ctx, audioObjectName)
 	if err != nil {
 		log.Printf("删除新格式音频失败: %v", err)
 		// 继续执行，尝试删除旧格式
 	}
-	
+
 	// 尝试删除旧格式音频
-	err = s.minioClient.DeleteFile(ctx, oldAudioObjectName)
+	err = s.storageClient.DeleteFile(ctx, oldAudioObjectName)
 	if err != nil {
 		log.Printf("删除旧格式音频失败: %v", err)
 		// 继续执行，因为有可能音频不存在
@@ -448,7 +682,7 @@ func (s *Server) deleteContentHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "内容删除成功",
-		"date": date,
+		"date":    date,
 	})
 }
 
@@ -468,27 +702,21 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 
 	// 构建内容对象的键名
 	contentKey := fmt.Sprintf("content:%s:hacker-news:%s", s.config.Server.Env, date)
-	
+
 	// 定义变量用于存储内容
 	var podcastContent, blogContent, introContent string
-	var content struct {
-		Intro      string   `json:"intro"`
-		Podcast    string   `json:"podcast"`
-		Blog       string   `json:"blog"`
-		AudioURL   string   `json:"audioUrl"`
-		AudioFiles []string `json:"audioFiles"`
-	}
-	
+	var content episodeContent
+
 	// 检查该日期内容是否已存在
-	contentExists, err := s.minioClient.ObjectExists(ctx, contentKey)
+	contentExists, err := s.storageClient.ObjectExists(ctx, contentKey)
 	if err != nil {
 		log.Printf("检查内容是否存在失败: %v", err)
 	}
-	
+
 	// 如果内容不存在，生成内容
 	if !contentExists || force {
 		log.Printf("日期 %s 的内容不存在或强制重新生成，开始生成", date)
-		
+
 		// 步骤1: 获取热门文章
 		stories, err := s.hnClient.GetTopStories(date, maxItems)
 		if err != nil {
@@ -550,22 +778,16 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 			log.Printf("生成简介失败: %v", err)
 			return
 		}
-		
+
 		// 构建内容对象
-		content = struct {
-			Intro      string   `json:"intro"`
-			Podcast    string   `json:"podcast"`
-			Blog       string   `json:"blog"`
-			AudioURL   string   `json:"audioUrl"`
-			AudioFiles []string `json:"audioFiles"`
-		}{
+		content = episodeContent{
 			Intro:      introContent,
 			Podcast:    podcastContent,
 			Blog:       blogContent,
 			AudioURL:   "",
 			AudioFiles: []string{},
 		}
-		
+
 		// 序列化并保存内容
 		contentData, err := json.Marshal(content)
 		if err != nil {
@@ -574,48 +796,50 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 		}
 
 		// 上传内容
-		_, err = s.minioClient.UploadFile(ctx, contentKey, contentData, "application/json")
+		_, err = s.storageClient.UploadFile(ctx, contentKey, contentData, "application/json")
 		if err != nil {
 			log.Printf("上传内容失败: %v", err)
 			return
 		}
-		
+
 		log.Printf("内容生成完成，已保存到存储")
 	} else {
 		// 如果内容已存在，获取现有内容
 		log.Printf("日期 %s 的内容已存在，获取现有内容", date)
-		contentData, err := s.minioClient.DownloadFile(ctx, contentKey)
+		contentData, err := s.storageClient.DownloadFile(ctx, contentKey)
 		if err != nil {
 			log.Printf("获取现有内容失败: %v", err)
 			return
 		}
-		
+
 		// 解析内容
 		err = json.Unmarshal(contentData, &content)
 		if err != nil {
 			log.Printf("解析内容失败: %v", err)
 			return
 		}
-		
+
 		podcastContent = content.Podcast
 		introContent = content.Intro
-		
+
 		// 检查是否已有音频URL
 		if content.AudioURL != "" && !forceAudio {
 			log.Printf("音频已存在，URL: %s", content.AudioURL)
 			return
 		}
-		
+
 		log.Printf("音频不存在或强制重新生成，开始生成")
 	}
 
 	// 步骤6: 生成播客音频
 	log.Printf("开始生成播客音频")
 
-	// 生成播客主内容音频片段并收集
-	var audioSegments [][]byte
-
-
+	// 解析对话台词：按前缀确定说话者，去除前缀后得到实际朗读文本
+	type dialogueLine struct {
+		speaker string
+		text    string
+	}
+	var lines []dialogueLine
 	for _, conversation := range strings.Split(podcastContent, "\n") {
 		if strings.TrimSpace(conversation) == "" {
 			continue
@@ -627,7 +851,7 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 		}
 		// 日志：原始内容
 		log.Printf("原始对话: %q", conversation)
-	
+
 		// 优化前缀移除逻辑，兼容中英文冒号
 		text := conversation
 		if idx := strings.IndexAny(conversation, ":："); idx != -1 {
@@ -635,45 +859,62 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 		}
 		// 日志：处理后内容
 		log.Printf("去前缀后: %q", text)
-	
-		// 生成语音
-		audio, err := s.ttsService.SynthesizeSpeech(ctx, text, speaker)
-		if err != nil {
-			log.Printf("生成音频失败: %v", err)
-			continue
-		}
-		audioSegments = append(audioSegments, audio)
-	}
 
+		lines = append(lines, dialogueLine{speaker: speaker, text: text})
+	}
 
+	// 生成播客主内容音频片段并收集。同一说话人的连续台词合并为一段SSML，
+	// 每行台词之间用BuildSSML插入停顿，既保留自然的换气间隔，又减少TTS调用次数
+	var audioSegments [][]byte
+	for i := 0; i < len(lines); {
+		j := i + 1
+		for j < len(lines) && lines[j].speaker == lines[i].speaker {
+			j++
+		}
 
+		sections := make([]string, 0, j-i)
+		for _, l := range lines[i:j] {
+			sections = append(sections, l.text)
+		}
 
+		ssml := tts.BuildSSML(sections, 300*time.Millisecond)
+		audio, err := s.ttsService.Synthesize(ctx, tts.SynthesisRequest{SSML: ssml, Speaker: lines[i].speaker})
+		if err != nil {
+			log.Printf("生成音频失败: %v", err)
+		} else {
+			audioSegments = append(audioSegments, audio)
+		}
 
+		i = j
+	}
 
 	// 合并所有音频片段并上传
 	if len(audioSegments) > 0 {
-		mergedAudio, err := mergeAudioBytes(ctx, audioSegments)
+		mergedAudio, durationMs, err := mergeAudioBytesWithGap(ctx, audioSegments, 0)
 		if err != nil {
 			log.Printf("合并音频失败: %v", err)
+		} else if !s.passesModeration(ctx, mergedAudio) {
+			log.Printf("合并音频未通过内容审核，拒绝发布，日期: %s", date)
 		} else {
 			mergedAudioKey := fmt.Sprintf("audio/%s-complete.mp3", date)
-			mergedAudioURL, err := s.minioClient.UploadFile(ctx, mergedAudioKey, mergedAudio, "audio/mpeg")
+			mergedAudioURL, err := s.storageClient.UploadFile(ctx, mergedAudioKey, mergedAudio, "audio/mpeg")
 			if err != nil {
 				log.Printf("上传合并音频失败: %v", err)
 			} else {
 				content.AudioURL = mergedAudioURL
+				content.Duration = durationMs / 1000
 			}
 		}
 	}
 
 	// 生成并上传简介音频（intro）
-	_, err = s.ttsService.SynthesizeSpeech(ctx, introContent, "男")
+	_, err = s.ttsService.Synthesize(ctx, tts.SynthesisRequest{Text: introContent, Speaker: "男"})
 	if err != nil {
 		log.Printf("生成简介音频失败: %v", err)
-	} 
+	}
 	// else {
 	// 	introKey := fmt.Sprintf("audio/%s-intro.mp3", date)
-	// 	introURL, err := s.minioClient.UploadFile(ctx, introKey, introAudio, "audio/mpeg")
+	// 	introURL, err := s.storageClient.UploadFile(ctx, introKey, introAudio, "audio/mpeg")
 	// 	if err != nil {
 	// 		log.Printf("上传简介音频失败: %v", err)
 	// 	} else {
@@ -687,7 +928,7 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 		log.Printf("序列化内容失败: %v", err)
 		return
 	}
-	_, err = s.minioClient.UploadFile(ctx, contentKey, contentData, "application/json")
+	_, err = s.storageClient.UploadFile(ctx, contentKey, contentData, "application/json")
 	if err != nil {
 		log.Printf("上传内容失败: %v", err)
 		return
@@ -696,35 +937,80 @@ func (s *Server) processHackerNews(date string, maxItems int, force bool, forceA
 	log.Printf("处理完成，日期: %s", date)
 }
 
-func mergeAudioBytes(ctx context.Context, audioSegments [][]byte) ([]byte, error) {
-    tempDir, err := os.MkdirTemp("", "audio-merge")
-    if err != nil {
-        return nil, err
-    }
-    defer os.RemoveAll(tempDir)
-
-    var fileListPath = filepath.Join(tempDir, "filelist.txt")
-    fileList, err := os.Create(fileListPath)
-    if err != nil {
-        return nil, err
-    }
-    defer fileList.Close()
-
-    var segmentFiles []string
-    for i, segment := range audioSegments {
-        segPath := filepath.Join(tempDir, fmt.Sprintf("seg%d.mp3", i))
-        if err := os.WriteFile(segPath, segment, 0644); err != nil {
-            return nil, err
-        }
-        fmt.Fprintf(fileList, "file '%s'\n", segPath)
-        segmentFiles = append(segmentFiles, segPath)
-    }
-    fileList.Sync()
-
-    outPath := filepath.Join(tempDir, "merged.mp3")
-    cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-f", "concat", "-safe", "0", "-i", fileListPath, "-c", "copy", outPath)
-    if err := cmd.Run(); err != nil {
-        return nil, err
-    }
-    return os.ReadFile(outPath)
-}
\ No newline at end of file
+// mergeAudioBytesWithGap 合并多个MP3片段，可在片段之间插入静音间隔。
+// 优先走纯Go的帧级拼接（internal/audio），无需ffmpeg即可拿到精确时长；
+// 只有遇到无法识别为MP3帧流的片段时，才回退到shell出ffmpeg重新编码
+func mergeAudioBytesWithGap(ctx context.Context, audioSegments [][]byte, gapMs int) ([]byte, int, error) {
+	merged, durationMs, err := audio.Concat(audioSegments, gapMs)
+	if err == nil {
+		return merged, durationMs, nil
+	}
+	if !errors.Is(err, audio.ErrUnsupportedSegment) {
+		return nil, 0, err
+	}
+	log.Printf("纯Go拼接失败，回退到ffmpeg: %v", err)
+
+	tempDir, err := os.MkdirTemp("", "audio-merge")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	var silencePath string
+	if gapMs > 0 {
+		silencePath = filepath.Join(tempDir, "silence.mp3")
+		cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-f", "lavfi", "-i", "anullsrc=r=16000:cl=mono",
+			"-t", fmt.Sprintf("%.3f", float64(gapMs)/1000), silencePath)
+		if err := cmd.Run(); err != nil {
+			return nil, 0, fmt.Errorf("生成静音间隔失败: %w", err)
+		}
+	}
+
+	fileListPath := filepath.Join(tempDir, "filelist.txt")
+	fileList, err := os.Create(fileListPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, segment := range audioSegments {
+		segPath := filepath.Join(tempDir, fmt.Sprintf("seg%d.mp3", i))
+		if err := os.WriteFile(segPath, segment, 0644); err != nil {
+			fileList.Close()
+			return nil, 0, err
+		}
+		fmt.Fprintf(fileList, "file '%s'\n", segPath)
+
+		if silencePath != "" && i < len(audioSegments)-1 {
+			fmt.Fprintf(fileList, "file '%s'\n", silencePath)
+		}
+	}
+	if err := fileList.Sync(); err != nil {
+		fileList.Close()
+		return nil, 0, err
+	}
+	fileList.Close()
+
+	outPath := filepath.Join(tempDir, "merged.mp3")
+	copyCmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-f", "concat", "-safe", "0", "-i", fileListPath, "-c", "copy", outPath)
+	if err := copyCmd.Run(); err != nil {
+		log.Printf("直接拼接失败，回退到重新编码: %v", err)
+		reencodeCmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-f", "concat", "-safe", "0", "-i", fileListPath,
+			"-c:a", "libmp3lame", "-ar", "16000", "-b:a", "32k", outPath)
+		if err := reencodeCmd.Run(); err != nil {
+			return nil, 0, fmt.Errorf("合并音频失败: %w", err)
+		}
+	}
+
+	mergedBytes, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	duration, err := mp3duration.Calculate(outPath)
+	if err != nil {
+		log.Printf("计算合并音频时长失败: %v", err)
+		return mergedBytes, 0, nil
+	}
+
+	return mergedBytes, int(duration * 1000), nil
+}
@@ -0,0 +1,204 @@
+// Package audio 提供不依赖外部二进制的纯Go音频处理能力，
+// 目前只覆盖MP3（MPEG Layer III）帧级拼接与时长计算
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnsupportedSegment 在某个片段无法被识别为MP3帧流时返回，
+// 调用方应据此回退到基于ffmpeg的重新编码路径
+var ErrUnsupportedSegment = errors.New("音频片段不是可识别的MP3帧流")
+
+const frameHeaderSize = 4
+
+// 按MPEG版本+层区分的比特率表（单位kbps），索引0表示free，-1表示保留/非法
+var mpeg1Layer3Bitrates = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, -1}
+var mpeg2Layer3Bitrates = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, -1}
+
+// 按MPEG版本区分的采样率表（单位Hz），索引3为保留值
+var mpeg1SampleRates = [4]int{44100, 48000, 32000, -1}
+var mpeg2SampleRates = [4]int{22050, 24000, 16000, -1}
+var mpeg25SampleRates = [4]int{11025, 12000, 8000, -1}
+
+// mp3Frame 是一帧已解析的Layer III音频帧
+type mp3Frame struct {
+	raw        []byte // 含4字节帧头在内的完整帧数据
+	sampleRate int
+	samples    int // 每帧采样数：MPEG1为1152，MPEG2/2.5为576
+}
+
+// parseFrameHeader 解析4字节MP3帧头，返回采样率、每帧采样数与帧总长度（字节，含帧头）。
+// 只接受Layer III帧，其余一律视为不合法
+func parseFrameHeader(b []byte) (sampleRate, samples, frameLen int, ok bool) {
+	if len(b) < frameHeaderSize {
+		return 0, 0, 0, false
+	}
+	// 11位同步字 0xFFE
+	if b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return 0, 0, 0, false
+	}
+
+	version := (b[1] >> 3) & 0x3
+	layer := (b[1] >> 1) & 0x3
+	if layer != 0x1 { // 01 = Layer III，其余不处理
+		return 0, 0, 0, false
+	}
+
+	bitrateIdx := (b[2] >> 4) & 0xF
+	samplerateIdx := (b[2] >> 2) & 0x3
+	padding := int((b[2] >> 1) & 0x1)
+
+	var bitrateKbps int
+	var rates [4]int
+	var frameLenMultiplier int
+	switch version {
+	case 0x3: // MPEG1
+		bitrateKbps = mpeg1Layer3Bitrates[bitrateIdx]
+		rates = mpeg1SampleRates
+		samples = 1152
+		frameLenMultiplier = 144
+	case 0x2: // MPEG2
+		bitrateKbps = mpeg2Layer3Bitrates[bitrateIdx]
+		rates = mpeg2SampleRates
+		samples = 576
+		frameLenMultiplier = 72
+	case 0x0: // MPEG2.5
+		bitrateKbps = mpeg2Layer3Bitrates[bitrateIdx]
+		rates = mpeg25SampleRates
+		samples = 576
+		frameLenMultiplier = 72
+	default: // 0x1 保留
+		return 0, 0, 0, false
+	}
+
+	sampleRate = rates[samplerateIdx]
+	if bitrateKbps <= 0 || sampleRate <= 0 {
+		return 0, 0, 0, false
+	}
+
+	// MPEG1每帧1152个采样，帧长系数为144；MPEG2/2.5每帧只有576个采样，
+	// 帧长系数相应减半为72，否则会把帧长算成两倍，扫描后续帧头时直接错位
+	frameLen = frameLenMultiplier*bitrateKbps*1000/sampleRate + padding
+	if frameLen <= frameHeaderSize {
+		return 0, 0, 0, false
+	}
+
+	return sampleRate, samples, frameLen, true
+}
+
+// syncsafeSize 解码ID3v2使用的4字节syncsafe整数（每字节仅低7位有效）
+func syncsafeSize(b []byte) int {
+	return int(b[0])<<21 | int(b[1])<<14 | int(b[2])<<7 | int(b[3])
+}
+
+// stripID3 去掉片段开头的ID3v2标签和结尾的ID3v1标签（如果存在）
+func stripID3(data []byte) []byte {
+	if len(data) >= 10 && string(data[0:3]) == "ID3" {
+		size := syncsafeSize(data[6:10])
+		if 10+size <= len(data) {
+			data = data[10+size:]
+		}
+	}
+	if len(data) >= 128 && string(data[len(data)-128:len(data)-125]) == "TAG" {
+		data = data[:len(data)-128]
+	}
+	return data
+}
+
+// parseFrames 扫描音频数据中全部合法的MP3帧，跳过中间无法识别的字节。
+// 如果全程找不到任何一帧，说明这不是MP3数据，返回ErrUnsupportedSegment
+func parseFrames(data []byte) ([]mp3Frame, error) {
+	data = stripID3(data)
+
+	var frames []mp3Frame
+	offset := 0
+	for offset+frameHeaderSize <= len(data) {
+		sampleRate, samples, frameLen, ok := parseFrameHeader(data[offset:])
+		if !ok {
+			offset++
+			continue
+		}
+		if offset+frameLen > len(data) {
+			break // 末尾不完整的一帧，丢弃
+		}
+		frames = append(frames, mp3Frame{
+			raw:        data[offset : offset+frameLen],
+			sampleRate: sampleRate,
+			samples:    samples,
+		})
+		offset += frameLen
+	}
+
+	if len(frames) == 0 {
+		return nil, ErrUnsupportedSegment
+	}
+	return frames, nil
+}
+
+const (
+	silenceFrameSampleRate = 16000
+	silenceFrameSamples    = 576
+)
+
+// silenceFrame 是一帧16kHz单声道32kbps的静音MP3帧（MPEG2 Layer III，144字节），
+// 由固定帧头加全零负载构成，内嵌在二进制里，
+// 这样纯Go拼接路径插入静音间隔时无需再每次shell出ffmpeg
+var silenceFrame = append([]byte{0xFF, 0xF3, 0x48, 0xC0}, make([]byte, 140)...)
+
+// Concat 用纯Go方式拼接多个MP3片段，可在相邻片段之间插入静音间隔（单位毫秒），
+// 返回合并后的字节流与精确时长（毫秒）。
+// 当任一片段不是可识别的MP3帧流时返回ErrUnsupportedSegment，调用方应回退到ffmpeg
+func Concat(segments [][]byte, gapMs int) ([]byte, int, error) {
+	var buf bytes.Buffer
+	var durationMs float64
+
+	gapFrames := 0
+	if gapMs > 0 {
+		frameDurationMs := float64(silenceFrameSamples) * 1000 / float64(silenceFrameSampleRate)
+		gapFrames = int(float64(gapMs)/frameDurationMs + 0.5)
+		if gapFrames < 1 {
+			gapFrames = 1
+		}
+	}
+
+	for i, segment := range segments {
+		frames, err := parseFrames(segment)
+		if err != nil {
+			return nil, 0, fmt.Errorf("第%d个片段: %w", i, err)
+		}
+
+		for _, f := range frames {
+			buf.Write(f.raw)
+			durationMs += float64(f.samples) * 1000 / float64(f.sampleRate)
+		}
+
+		if gapMs > 0 && i < len(segments)-1 {
+			for j := 0; j < gapFrames; j++ {
+				buf.Write(silenceFrame)
+			}
+			durationMs += float64(gapFrames) * float64(silenceFrameSamples) * 1000 / float64(silenceFrameSampleRate)
+		}
+	}
+
+	return buf.Bytes(), int(durationMs), nil
+}
+
+// Duration 解析MP3帧头并累加每帧的采样时长，得到精确时长（对VBR安全：
+// 不假设恒定比特率，而是逐帧读取真实的采样率和采样数）。
+// 当数据不是可识别的MP3帧流时返回ErrUnsupportedSegment
+func Duration(data []byte) (time.Duration, error) {
+	frames, err := parseFrames(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var seconds float64
+	for _, f := range frames {
+		seconds += float64(f.samples) / float64(f.sampleRate)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,186 @@
+package audio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// mpeg1Layer3BitratesKbps和mpeg1SampleRatesHz与mp3.go中的比特率/采样率表保持一致，
+// 用于按已知参数构造CBR/VBR测试夹具，而不依赖外部二进制文件
+var mpeg1Layer3BitratesKbps = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, -1}
+var mpeg1SampleRatesHz = [4]int{44100, 48000, 32000, -1}
+
+// buildMPEG1Frame 构造一帧合法的MPEG1 Layer III帧（帧头+零填充payload），
+// 供测试拼装CBR/VBR夹具
+func buildMPEG1Frame(bitrateIdx, samplerateIdx, padding int) []byte {
+	bitrateKbps := mpeg1Layer3BitratesKbps[bitrateIdx]
+	sampleRate := mpeg1SampleRatesHz[samplerateIdx]
+	frameLen := 144*bitrateKbps*1000/sampleRate + padding
+
+	frame := make([]byte, frameLen)
+	frame[0] = 0xFF
+	frame[1] = 0xFB // MPEG1, Layer III, 无CRC
+	frame[2] = byte(bitrateIdx<<4 | samplerateIdx<<2 | padding<<1)
+	frame[3] = 0x00
+	return frame
+}
+
+// mpeg2Layer3BitratesKbps和mpeg2SampleRatesHz与mp3.go中MPEG2的比特率/采样率表
+// 保持一致，用于构造16/22.05/24kHz（如腾讯TTS）的测试夹具
+var mpeg2Layer3BitratesKbps = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, -1}
+var mpeg2SampleRatesHz = [4]int{22050, 24000, 16000, -1}
+
+// buildMPEG2Frame 构造一帧合法的MPEG2 Layer III帧（帧头+零填充payload）。
+// 与MPEG1的关键区别是每帧只携带576个采样，帧长系数为72而不是144
+func buildMPEG2Frame(bitrateIdx, samplerateIdx, padding int) []byte {
+	bitrateKbps := mpeg2Layer3BitratesKbps[bitrateIdx]
+	sampleRate := mpeg2SampleRatesHz[samplerateIdx]
+	frameLen := 72*bitrateKbps*1000/sampleRate + padding
+
+	frame := make([]byte, frameLen)
+	frame[0] = 0xFF
+	frame[1] = 0xF3 // MPEG2, Layer III, 无CRC
+	frame[2] = byte(bitrateIdx<<4 | samplerateIdx<<2 | padding<<1)
+	frame[3] = 0x00
+	return frame
+}
+
+// TestDuration_MPEG2 验证16kHz MPEG2 Layer III（如腾讯TTS的输出）的时长计算：
+// 每帧只有576个采样，帧长系数须为72，否则帧长会被算成两倍，
+// 导致扫描到的帧数腰斩、时长算出来只有真实值的一半
+func TestDuration_MPEG2(t *testing.T) {
+	const bitrateIdx = 4    // 32kbps
+	const samplerateIdx = 2 // 16000Hz
+	const frameCount = 21
+
+	var buf bytes.Buffer
+	for i := 0; i < frameCount; i++ {
+		buf.Write(buildMPEG2Frame(bitrateIdx, samplerateIdx, 0))
+	}
+
+	got, err := Duration(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Duration() 返回错误: %v", err)
+	}
+
+	frames := float64(frameCount)
+	want := time.Duration(frames * 576 / 16000 * float64(time.Second))
+	if diff := got - want; diff > time.Millisecond || diff < -time.Millisecond {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+}
+
+// TestConcat_MPEG2OddFrameCount 验证帧长系数修正后，Concat在奇数帧的MPEG2流上
+// 也能拼出全部帧而不会丢尾帧
+func TestConcat_MPEG2OddFrameCount(t *testing.T) {
+	const bitrateIdx = 4    // 32kbps
+	const samplerateIdx = 2 // 16000Hz
+	const frameCount = 21
+
+	var segment bytes.Buffer
+	for i := 0; i < frameCount; i++ {
+		segment.Write(buildMPEG2Frame(bitrateIdx, samplerateIdx, 0))
+	}
+
+	merged, durationMs, err := Concat([][]byte{segment.Bytes()}, 0)
+	if err != nil {
+		t.Fatalf("Concat() 返回错误: %v", err)
+	}
+
+	if len(merged) != segment.Len() {
+		t.Errorf("Concat()拼接后的字节数 = %d, want %d（不应丢失末尾帧）", len(merged), segment.Len())
+	}
+
+	wantMs := int(float64(frameCount) * 576 / 16000 * 1000)
+	if durationMs != wantMs {
+		t.Errorf("Concat()时长 = %dms, want %dms", durationMs, wantMs)
+	}
+}
+
+// TestDuration_CBR 验证恒定比特率MP3的时长计算：每帧固定携带1152个采样，
+// N帧的总时长应为 N*1152/采样率
+func TestDuration_CBR(t *testing.T) {
+	const bitrateIdx = 9    // 128kbps
+	const samplerateIdx = 0 // 44100Hz
+	const frameCount = 50
+
+	var buf bytes.Buffer
+	for i := 0; i < frameCount; i++ {
+		buf.Write(buildMPEG1Frame(bitrateIdx, samplerateIdx, 0))
+	}
+
+	got, err := Duration(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Duration() 返回错误: %v", err)
+	}
+
+	frames := float64(frameCount)
+	want := time.Duration(frames * 1152 / 44100 * float64(time.Second))
+	if diff := got - want; diff > time.Millisecond || diff < -time.Millisecond {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+}
+
+// TestDuration_VBR 验证可变比特率MP3的时长计算：相邻帧的比特率（进而帧长）各不相同，
+// Duration必须按每帧真实的帧头解析结果累加采样数，而不能假设恒定帧长
+func TestDuration_VBR(t *testing.T) {
+	const samplerateIdx = 0 // 44100Hz
+	bitrateIdxSequence := []int{1, 5, 9, 14, 3, 8, 12, 6, 2, 10}
+
+	var buf bytes.Buffer
+	for _, idx := range bitrateIdxSequence {
+		buf.Write(buildMPEG1Frame(idx, samplerateIdx, 0))
+	}
+
+	got, err := Duration(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Duration() 返回错误: %v", err)
+	}
+
+	want := time.Duration(float64(len(bitrateIdxSequence)) * 1152 / 44100 * float64(time.Second))
+	if diff := got - want; diff > time.Millisecond || diff < -time.Millisecond {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+}
+
+// TestDuration_VBRWithID3AndJunk 验证VBR夹具前后分别带ID3v2/v1标签、
+// 帧间夹杂无法识别字节时，Duration仍能正确跳过标签和垃圾字节，只统计合法帧
+func TestDuration_VBRWithID3AndJunk(t *testing.T) {
+	const samplerateIdx = 1 // 48000Hz
+	bitrateIdxSequence := []int{2, 7, 11, 4}
+
+	id3 := make([]byte, 10)
+	copy(id3, []byte("ID3"))
+	id3[3], id3[4] = 3, 0 // 版本号
+	// syncsafe大小字段全为0，表示标签体长度为0（仅10字节头部）
+
+	var buf bytes.Buffer
+	buf.Write(id3)
+	for i, idx := range bitrateIdxSequence {
+		if i == 2 {
+			buf.Write([]byte{0x00, 0x01, 0x02}) // 帧间垃圾字节，不构成合法帧头
+		}
+		buf.Write(buildMPEG1Frame(idx, samplerateIdx, 0))
+	}
+	buf.WriteString("TAG")
+	buf.Write(make([]byte, 125))
+
+	got, err := Duration(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Duration() 返回错误: %v", err)
+	}
+
+	want := time.Duration(float64(len(bitrateIdxSequence)) * 1152 / 48000 * float64(time.Second))
+	if diff := got - want; diff > time.Millisecond || diff < -time.Millisecond {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+}
+
+// TestDuration_UnsupportedSegment 验证非MP3数据会返回ErrUnsupportedSegment
+func TestDuration_UnsupportedSegment(t *testing.T) {
+	_, err := Duration([]byte("this is not an mp3 frame stream"))
+	if err != ErrUnsupportedSegment {
+		t.Fatalf("Duration() 错误 = %v, 期望 ErrUnsupportedSegment", err)
+	}
+}
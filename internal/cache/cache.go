@@ -0,0 +1,23 @@
+// Package cache 提供通用的键值缓存抽象，
+// 供ai、crawler、tts等包复用以避免重复调用昂贵的外部服务
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache 是缓存后端的统一接口，内存LRU、Redis、Memcached都实现该接口
+type Cache interface {
+	// Get 读取缓存值，ok为false表示未命中或已过期
+	Get(ctx context.Context, key string) (val []byte, ok bool)
+
+	// Set 写入缓存值，ttl<=0表示永不过期
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration)
+
+	// Delete 删除缓存值
+	Delete(ctx context.Context, key string)
+
+	// Exists 检查键是否存在且未过期
+	Exists(ctx context.Context, key string) bool
+}
@@ -0,0 +1,19 @@
+package cache
+
+import "hacker-news/config"
+
+// Factory 根据配置创建缓存后端
+func Factory(cfg *config.CacheConfig) (Cache, error) {
+	// 根据配置选择缓存后端
+	switch cfg.Provider {
+	case "redis":
+		return NewRedisCache(&cfg.Redis)
+	case "memcached":
+		return NewMemcachedCache(&cfg.Memcached)
+	case "memory":
+		return NewMemoryCache(&cfg.Memory)
+	default:
+		// 默认使用进程内LRU缓存
+		return NewMemoryCache(&cfg.Memory)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"hacker-news/config"
+	"log"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// MemcachedCache 是基于Memcached的缓存，实现Cache接口。
+// Memcached对key的长度(250字节)和字符都有限制，调用方应传入较短的键（如SHA-256十六进制摘要）
+type MemcachedCache struct {
+	client *memcache.Client
+}
+
+// NewMemcachedCache 创建一个新的Memcached缓存客户端
+func NewMemcachedCache(cfg *config.MemcachedCacheConfig) (*MemcachedCache, error) {
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{"localhost:11211"}
+	}
+
+	return &MemcachedCache{client: memcache.New(addrs...)}, nil
+}
+
+// Get 读取缓存值，ok为false表示未命中或已过期
+func (c *MemcachedCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	item, err := c.client.Get(key)
+	if err != nil {
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			log.Printf("从Memcached读取缓存失败: %v", err)
+		}
+		return nil, false
+	}
+	return item.Value, true
+}
+
+// Set 写入缓存值，ttl<=0表示永不过期
+func (c *MemcachedCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) {
+	var expiration int32 // 0在Memcached中表示永不过期
+	if ttl > 0 {
+		expiration = int32(ttl.Seconds())
+	}
+	err := c.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      val,
+		Expiration: expiration,
+	})
+	if err != nil {
+		log.Printf("写入Memcached缓存失败: %v", err)
+	}
+}
+
+// Delete 删除缓存值
+func (c *MemcachedCache) Delete(ctx context.Context, key string) {
+	if err := c.client.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		log.Printf("删除Memcached缓存失败: %v", err)
+	}
+}
+
+// Exists 检查键是否存在且未过期
+func (c *MemcachedCache) Exists(ctx context.Context, key string) bool {
+	_, ok := c.Get(ctx, key)
+	return ok
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"hacker-news/config"
+	"sync"
+	"time"
+)
+
+// MemoryCache 是进程内的LRU缓存，实现Cache接口。
+// 超过MaxEntries时淘汰最久未使用的条目，过期的条目在读取时惰性清理
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key       string
+	val       []byte
+	expiresAt time.Time // 零值表示永不过期
+}
+
+// NewMemoryCache 创建一个新的进程内LRU缓存
+func NewMemoryCache(cfg *config.MemoryCacheConfig) (*MemoryCache, error) {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}, nil
+}
+
+// Get 读取缓存值，ok为false表示未命中或已过期
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.items[key]
+	if !found {
+		return nil, false
+	}
+
+	entry := elem.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.val, true
+}
+
+// Set 写入缓存值，ttl<=0表示永不过期
+func (c *MemoryCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, found := c.items[key]; found {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.val = val
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	entry := &memoryCacheEntry{key: key, val: val, expiresAt: expiresAt}
+	elem := c.ll.PushFront(entry)
+	c.items[key] = elem
+
+	if c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// Delete 删除缓存值
+func (c *MemoryCache) Delete(ctx context.Context, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, found := c.items[key]; found {
+		c.removeElement(elem)
+	}
+}
+
+// Exists 检查键是否存在且未过期
+func (c *MemoryCache) Exists(ctx context.Context, key string) bool {
+	_, ok := c.Get(ctx, key)
+	return ok
+}
+
+// removeElement 从链表和索引中移除一个条目，调用方需持有c.mu
+func (c *MemoryCache) removeElement(elem *list.Element) {
+	c.ll.Remove(elem)
+	entry := elem.Value.(*memoryCacheEntry)
+	delete(c.items, entry.key)
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"hacker-news/config"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache 是基于Redis的缓存，实现Cache接口
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache 创建一个新的Redis缓存客户端
+func NewRedisCache(cfg *config.RedisCacheConfig) (*RedisCache, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	return &RedisCache{client: client}, nil
+}
+
+// Get 读取缓存值，ok为false表示未命中或已过期
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	val, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("从Redis读取缓存失败: %v", err)
+		}
+		return nil, false
+	}
+	return val, true
+}
+
+// Set 写入缓存值，ttl<=0表示永不过期
+func (c *RedisCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) {
+	if err := c.client.Set(ctx, key, val, ttl).Err(); err != nil {
+		log.Printf("写入Redis缓存失败: %v", err)
+	}
+}
+
+// Delete 删除缓存值
+func (c *RedisCache) Delete(ctx context.Context, key string) {
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		log.Printf("删除Redis缓存失败: %v", err)
+	}
+}
+
+// Exists 检查键是否存在且未过期
+func (c *RedisCache) Exists(ctx context.Context, key string) bool {
+	n, err := c.client.Exists(ctx, key).Result()
+	if err != nil {
+		log.Printf("检查Redis缓存是否存在失败: %v", err)
+		return false
+	}
+	return n > 0
+}
@@ -0,0 +1,31 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// fetchWithBrowser 用无头Chrome渲染目标页面并返回渲染后的HTML，
+// 用于应对Fetcher.Fetch检测到的JS渲染空壳页面
+func fetchWithBrowser(ctx context.Context, targetURL string) (string, error) {
+	browserCtx, cancel := chromedp.NewContext(ctx)
+	defer cancel()
+
+	browserCtx, timeoutCancel := context.WithTimeout(browserCtx, 20*time.Second)
+	defer timeoutCancel()
+
+	var html string
+	err := chromedp.Run(browserCtx,
+		chromedp.Navigate(targetURL),
+		chromedp.Sleep(1*time.Second), // 等待首屏JS渲染完成
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	if err != nil {
+		return "", fmt.Errorf("无头浏览器渲染失败: %w", err)
+	}
+
+	return html, nil
+}
@@ -0,0 +1,294 @@
+package crawler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ArticleExtractor 从抓取到的原始响应中提取正文内容，不同实现针对不同的
+// 内容类型或站点（网页/PDF/Jina兜底）
+type ArticleExtractor interface {
+	// CanHandle 判断该提取器是否适合处理给定的URL和Content-Type
+	CanHandle(articleURL string, contentType string) bool
+
+	// Extract 从原始响应体中提取正文，返回Markdown格式的文本
+	Extract(ctx context.Context, articleURL string, contentType string, body []byte) (string, error)
+}
+
+// noisyTags 是Readability清洗阶段直接丢弃的标签，几乎不可能包含正文
+var noisyTags = []string{"script", "style", "nav", "footer", "header", "aside", "form", "noscript", "iframe"}
+
+// ReadabilityExtractor 是一个纯Go实现的类Readability正文提取器：
+// 去除导航/广告等噪声标签，按链接密度给候选区块打分，保留得分最高的子树，
+// 再将其转换为Markdown
+type ReadabilityExtractor struct{}
+
+// NewReadabilityExtractor 创建一个新的Readability提取器
+func NewReadabilityExtractor() *ReadabilityExtractor {
+	return &ReadabilityExtractor{}
+}
+
+// CanHandle Readability提取器是网页内容的默认兜底实现
+func (r *ReadabilityExtractor) CanHandle(articleURL string, contentType string) bool {
+	return contentType == "" || strings.Contains(contentType, "text/html")
+}
+
+// Extract 解析HTML、清洗噪声标签、按链接密度挑选正文子树并转换为Markdown
+func (r *ReadabilityExtractor) Extract(ctx context.Context, articleURL string, contentType string, body []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return "", fmt.Errorf("解析HTML失败: %w", err)
+	}
+
+	for _, tag := range noisyTags {
+		doc.Find(tag).Remove()
+	}
+
+	if selector := siteSpecificSelector(articleURL); selector != "" {
+		if sel := doc.Find(selector); sel.Length() > 0 {
+			return htmlToMarkdown(sel), nil
+		}
+	}
+
+	best := bestContentNode(doc)
+	if best == nil {
+		return htmlToMarkdown(doc.Find("body")), nil
+	}
+	return htmlToMarkdown(best), nil
+}
+
+// siteSpecificSelector 对已知站点结构返回专用的正文选择器，
+// 比如GitHub README、arXiv摘要页，这类页面用通用打分算法效果不稳定
+func siteSpecificSelector(articleURL string) string {
+	u, err := url.Parse(articleURL)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case strings.Contains(u.Host, "github.com"):
+		return "article.markdown-body"
+	case strings.Contains(u.Host, "arxiv.org"):
+		return "blockquote.abstract"
+	default:
+		return ""
+	}
+}
+
+// bestContentNode 遍历article/main/div候选区块，按链接密度和文本长度打分，
+// 返回得分最高的子树；链接密度越低、文本越长，越可能是正文
+func bestContentNode(doc *goquery.Document) *goquery.Selection {
+	candidates := doc.Find("article, main, div")
+	if candidates.Length() == 0 {
+		return nil
+	}
+
+	var best *goquery.Selection
+	bestScore := -1.0
+
+	candidates.Each(func(i int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		textLen := len([]rune(text))
+		if textLen < 140 {
+			return
+		}
+
+		score := float64(textLen) * (1 - linkDensity(s))
+		if score > bestScore {
+			bestScore = score
+			best = s
+		}
+	})
+
+	return best
+}
+
+// linkDensity 计算一个区块内链接文本占总文本的比例，比例越高越像导航/推荐列表
+func linkDensity(s *goquery.Selection) float64 {
+	totalLen := len([]rune(strings.TrimSpace(s.Text())))
+	if totalLen == 0 {
+		return 1
+	}
+
+	linkLen := 0
+	s.Find("a").Each(func(i int, a *goquery.Selection) {
+		linkLen += len([]rune(strings.TrimSpace(a.Text())))
+	})
+
+	return float64(linkLen) / float64(totalLen)
+}
+
+// htmlToMarkdown 把一个选区转换为简单的Markdown：标题保留#前缀，
+// 链接保留[text](href)，其余文本按块级元素换行拼接
+func htmlToMarkdown(s *goquery.Selection) string {
+	var sb strings.Builder
+
+	s.Find("h1, h2, h3, h4, p, li, blockquote, pre, a").Each(func(i int, el *goquery.Selection) {
+		text := strings.TrimSpace(el.Text())
+		if text == "" {
+			return
+		}
+
+		switch goquery.NodeName(el) {
+		case "h1":
+			sb.WriteString("# " + text + "\n\n")
+		case "h2":
+			sb.WriteString("## " + text + "\n\n")
+		case "h3", "h4":
+			sb.WriteString("### " + text + "\n\n")
+		case "li":
+			sb.WriteString("- " + text + "\n")
+		case "a":
+			if href, ok := el.Attr("href"); ok && el.Parent().Length() > 0 && goquery.NodeName(el.Parent()) != "p" {
+				sb.WriteString(fmt.Sprintf("[%s](%s)\n", text, href))
+			}
+		default:
+			sb.WriteString(text + "\n\n")
+		}
+	})
+
+	markdown := strings.TrimSpace(sb.String())
+	if markdown == "" {
+		return strings.TrimSpace(s.Text())
+	}
+	return markdown
+}
+
+// PDFExtractor 处理Content-Type为application/pdf的文章，提取纯文本正文
+type PDFExtractor struct{}
+
+// NewPDFExtractor 创建一个新的PDF提取器
+func NewPDFExtractor() *PDFExtractor {
+	return &PDFExtractor{}
+}
+
+// CanHandle 仅处理application/pdf或.pdf结尾的URL
+func (p *PDFExtractor) CanHandle(articleURL string, contentType string) bool {
+	return strings.Contains(contentType, "application/pdf") || strings.HasSuffix(strings.ToLower(articleURL), ".pdf")
+}
+
+// Extract 逐页提取PDF文本并用空行拼接
+func (p *PDFExtractor) Extract(ctx context.Context, articleURL string, contentType string, body []byte) (string, error) {
+	text, err := extractPDFText(body)
+	if err != nil {
+		return "", fmt.Errorf("提取PDF文本失败: %w", err)
+	}
+	return text, nil
+}
+
+// JinaExtractor 用Jina Reader(https://r.jina.ai)作为兜底，
+// 当本地提取器解析不出有效正文时使用，需要配置jinaKey
+type JinaExtractor struct {
+	jinaKey string
+}
+
+// NewJinaExtractor 创建一个新的Jina Reader兜底提取器
+func NewJinaExtractor(jinaKey string) *JinaExtractor {
+	return &JinaExtractor{jinaKey: jinaKey}
+}
+
+// CanHandle 只有配置了jinaKey时才可用
+func (j *JinaExtractor) CanHandle(articleURL string, contentType string) bool {
+	return j.jinaKey != ""
+}
+
+// Extract 请求Jina Reader把目标URL转换为Markdown正文，忽略本地body参数
+func (j *JinaExtractor) Extract(ctx context.Context, articleURL string, contentType string, body []byte) (string, error) {
+	readerURL := "https://r.jina.ai/" + articleURL
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readerURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+j.jinaKey)
+	req.Header.Set("X-Return-Format", "markdown")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("请求Jina Reader失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Jina Reader返回错误状态: %s", resp.Status)
+	}
+
+	buf := make([]byte, 0, 64*1024)
+	chunk := make([]byte, 4096)
+	for {
+		n, readErr := resp.Body.Read(chunk)
+		if n > 0 {
+			buf = append(buf, chunk[:n]...)
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	return string(buf), nil
+}
+
+// isKnownSPAHost 判断URL是否属于常见的JS渲染单页应用站点，
+// 这类站点用普通HTTP抓取往往拿不到真实正文
+var spaHostPattern = regexp.MustCompile(`(?i)(^|\.)(twitter\.com|x\.com|medium\.com|notion\.so)$`)
+
+func isKnownSPAHost(articleURL string) bool {
+	u, err := url.Parse(articleURL)
+	if err != nil {
+		return false
+	}
+	return spaHostPattern.MatchString(u.Host)
+}
+
+// diskArticleCache 把提取后的正文按URL哈希缓存到磁盘，供重复运行时跳过重新抓取
+type diskArticleCache struct {
+	dir string
+}
+
+// newDiskArticleCache 创建一个新的磁盘缓存，dir为空时表示不启用磁盘缓存
+func newDiskArticleCache(dir string) *diskArticleCache {
+	return &diskArticleCache{dir: dir}
+}
+
+// diskCacheKey 以文章URL的SHA-256摘要作为文件名
+func diskCacheKey(articleURL string) string {
+	h := sha256.Sum256([]byte(articleURL))
+	return hex.EncodeToString(h[:])
+}
+
+func (d *diskArticleCache) get(articleURL string) (string, bool) {
+	if d.dir == "" {
+		return "", false
+	}
+
+	path := filepath.Join(d.dir, diskCacheKey(articleURL)+".md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func (d *diskArticleCache) set(articleURL string, content string) {
+	if d.dir == "" || content == "" {
+		return
+	}
+
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return
+	}
+
+	path := filepath.Join(d.dir, diskCacheKey(articleURL)+".md")
+	_ = os.WriteFile(path, []byte(content), 0o644)
+}
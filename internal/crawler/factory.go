@@ -0,0 +1,17 @@
+package crawler
+
+import (
+	"hacker-news/config"
+	"hacker-news/internal/cache"
+	"time"
+)
+
+// NewNewsSource 按cfg.Source创建对应的NewsSource实现，默认回退到HTMLHNClient
+func NewNewsSource(cfg *config.HackerNewsConfig, c cache.Cache, cacheTTL time.Duration) NewsSource {
+	switch cfg.Source {
+	case "firebase":
+		return NewFirebaseHNClient(c, cacheTTL)
+	default:
+		return NewHTMLHNClient(cfg.JinaKey, c, cacheTTL, cfg.ArticleCacheDir)
+	}
+}
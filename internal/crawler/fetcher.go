@@ -0,0 +1,260 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// userAgents 是请求时轮换使用的浏览器User-Agent池，降低被目标站点识别并限流的概率
+var userAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/118.0",
+}
+
+// defaultHostQPS 是未显式配置的域名使用的默认限速，news.ycombinator.com专门限制为1/秒
+// 以遵守礼貌抓取的约定，避免被封禁
+const defaultHostQPS = 2.0
+
+var hostQPSOverrides = map[string]float64{
+	"news.ycombinator.com": 1.0,
+}
+
+// spaMarkers 是JS渲染单页应用常见的空壳HTML特征，命中时说明普通HTTP抓取拿不到真实内容
+var spaMarkers = []string{
+	`id="root"`,
+	`id="__next"`,
+	`id="app"`,
+	"<noscript>You need to enable JavaScript",
+}
+
+// Fetcher 是crawler包内统一的HTTP抓取子系统：共享连接池的http.Client，
+// 按域名做令牌桶限速，429/5xx时按Retry-After或指数退避+抖动重试，
+// 轮换User-Agent，必要时降级到chromedp无头浏览器渲染。
+// 所有抓取方法都通过一个有界并发信号量排队，避免调用方无界地开goroutine
+type Fetcher struct {
+	client      *http.Client
+	maxRetries  int
+	concurrency chan struct{}
+
+	limiterMu sync.Mutex
+	limiters  map[string]*rate.Limiter
+
+	uaCounter uint32
+}
+
+// NewFetcher 创建一个新的Fetcher，concurrency为允许的最大并发抓取数
+func NewFetcher(concurrency int) *Fetcher {
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+		maxRetries:  3,
+		concurrency: make(chan struct{}, concurrency),
+		limiters:    make(map[string]*rate.Limiter),
+	}
+}
+
+// FetchResult 是一次成功抓取的结果
+type FetchResult struct {
+	Body        []byte
+	ContentType string
+	StatusCode  int
+}
+
+// Fetch 抓取指定URL，按域名限速排队、失败时重试，必要时降级到无头浏览器。
+// 与旧实现不同，任何失败都会作为error返回，不会被吞掉
+func (f *Fetcher) Fetch(ctx context.Context, targetURL string) (*FetchResult, error) {
+	select {
+	case f.concurrency <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-f.concurrency }()
+
+	if err := f.waitRateLimit(ctx, targetURL); err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= f.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := f.sleepBackoff(ctx, attempt, lastErr); err != nil {
+				return nil, err
+			}
+		}
+
+		result, retryAfter, err := f.doFetch(ctx, targetURL)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if result.StatusCode == http.StatusTooManyRequests || result.StatusCode >= 500 {
+			lastErr = fmt.Errorf("抓取 %s 返回状态码 %d", targetURL, result.StatusCode)
+			if retryAfter > 0 {
+				if err := sleepCtx(ctx, retryAfter); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if result.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("抓取 %s 返回状态码 %d", targetURL, result.StatusCode)
+		}
+
+		if strings.Contains(result.ContentType, "text/html") && needsBrowserFallback(result.Body) {
+			if rendered, err := fetchWithBrowser(ctx, targetURL); err == nil {
+				result.Body = []byte(rendered)
+				result.ContentType = "text/html"
+			} else {
+				log.Printf("无头浏览器渲染降级失败，使用原始响应: %v %s", err, targetURL)
+			}
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("抓取 %s 失败，已重试%d次: %w", targetURL, f.maxRetries, lastErr)
+}
+
+// doFetch 发起一次HTTP请求，返回结果以及（如有）Retry-After建议的等待时长
+func (f *Fetcher) doFetch(ctx context.Context, targetURL string) (*FetchResult, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("User-Agent", f.nextUserAgent())
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml,application/pdf")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	return &FetchResult{
+		Body:        body,
+		ContentType: resp.Header.Get("Content-Type"),
+		StatusCode:  resp.StatusCode,
+	}, parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+// nextUserAgent 以轮询方式从User-Agent池中取下一个
+func (f *Fetcher) nextUserAgent() string {
+	i := atomic.AddUint32(&f.uaCounter, 1)
+	return userAgents[int(i)%len(userAgents)]
+}
+
+// waitRateLimit 阻塞直到目标域名的令牌桶放行，实现每域名的礼貌抓取限速
+func (f *Fetcher) waitRateLimit(ctx context.Context, targetURL string) error {
+	host := hostOf(targetURL)
+	limiter := f.limiterFor(host)
+	return limiter.Wait(ctx)
+}
+
+// limiterFor 返回域名对应的令牌桶限速器，不存在时按hostQPSOverrides或默认QPS创建
+func (f *Fetcher) limiterFor(host string) *rate.Limiter {
+	f.limiterMu.Lock()
+	defer f.limiterMu.Unlock()
+
+	if l, ok := f.limiters[host]; ok {
+		return l
+	}
+
+	qps := defaultHostQPS
+	if override, ok := hostQPSOverrides[host]; ok {
+		qps = override
+	}
+
+	l := rate.NewLimiter(rate.Limit(qps), 1)
+	f.limiters[host] = l
+	return l
+}
+
+// sleepBackoff 在重试前按指数退避加随机抖动等待，优先尊重上一次响应里的Retry-After语义
+// （该语义已经在调用处单独sleep过，这里只是常规指数退避）
+func (f *Fetcher) sleepBackoff(ctx context.Context, attempt int, lastErr error) error {
+	base := time.Duration(1<<uint(attempt-1)) * 500 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return sleepCtx(ctx, base+jitter)
+}
+
+// sleepCtx 休眠指定时长，若ctx提前取消则立即返回其错误
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter 解析Retry-After响应头（秒数形式），解析失败返回0表示不做特殊等待
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// hostOf 提取URL的主机名，解析失败时退化为整个URL本身（避免共用同一个限速桶）
+func hostOf(targetURL string) string {
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Host == "" {
+		return targetURL
+	}
+	return u.Host
+}
+
+// needsBrowserFallback 判断是否需要降级到无头浏览器：正文过短（<500字节）
+// 或命中常见SPA空壳标记，都说明服务端渲染的HTML里没有真实内容
+func needsBrowserFallback(body []byte) bool {
+	if len(body) < 500 {
+		return true
+	}
+
+	text := string(body)
+	for _, marker := range spaMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,332 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hacker-news/internal/cache"
+	"hacker-news/internal/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	firebaseBaseURL = "https://hacker-news.firebaseio.com/v0"
+	algoliaBaseURL  = "https://hn.algolia.com/api/v1"
+
+	// maxCommentDepth 限制评论楼层的递归抓取/渲染深度，避免深层嵌套讨论
+	// 消耗过多token和请求数
+	maxCommentDepth = 3
+
+	// maxCommentsPerStory 限制每个故事渲染进提示词的评论条数
+	maxCommentsPerStory = 60
+)
+
+// firebaseItem 是Firebase HN API item/{id}.json的响应结构
+type firebaseItem struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Text        string `json:"text"`
+	Score       int    `json:"score"`
+	Descendants int    `json:"descendants"`
+	Kids        []int  `json:"kids"`
+	Dead        bool   `json:"dead"`
+	Deleted     bool   `json:"deleted"`
+}
+
+// algoliaHit 是Algolia search_by_date接口返回的单条命中记录，
+// 用于Firebase topstories.json无法覆盖的“按日期查前台”场景
+type algoliaHit struct {
+	ObjectID string `json:"objectID"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+}
+
+type algoliaSearchResponse struct {
+	Hits []algoliaHit `json:"hits"`
+}
+
+// FirebaseHNClient 基于官方Firebase实时数据库API实现NewsSource，
+// 比解析HTML更稳定；Firebase本身不支持“按日期查前台”，该场景回退到Algolia搜索API
+type FirebaseHNClient struct {
+	cache    cache.Cache
+	cacheTTL time.Duration
+	fetcher  *Fetcher
+}
+
+// NewFirebaseHNClient 创建一个新的Firebase HN客户端，c为nil时不启用缓存
+func NewFirebaseHNClient(c cache.Cache, cacheTTL time.Duration) *FirebaseHNClient {
+	return &FirebaseHNClient{
+		cache:    c,
+		cacheTTL: cacheTTL,
+		fetcher:  NewFetcher(storyWorkerPoolSize),
+	}
+}
+
+// GetTopStories 获取指定日期的热门文章。日期为今天或空时直接用Firebase的
+// topstories.json（真正的实时热门榜），否则该日期是历史日期，
+// Firebase没有按日期查询的能力，回退到Algolia的front_page搜索
+func (f *FirebaseHNClient) GetTopStories(date string, maxItems int) ([]models.Story, error) {
+	ctx := context.Background()
+	today := time.Now().Format("2006-01-02")
+
+	if date == "" || date == today {
+		return f.topStoriesFromFirebase(ctx, maxItems)
+	}
+	return f.topStoriesFromAlgolia(ctx, date, maxItems)
+}
+
+// topStoriesFromFirebase 拉取topstories.json的ID列表，再并发取回每个item详情
+func (f *FirebaseHNClient) topStoriesFromFirebase(ctx context.Context, maxItems int) ([]models.Story, error) {
+	result, err := f.fetcher.Fetch(ctx, firebaseBaseURL+"/topstories.json")
+	if err != nil {
+		return nil, fmt.Errorf("获取topstories失败: %w", err)
+	}
+
+	var ids []int
+	if err := json.Unmarshal(result.Body, &ids); err != nil {
+		return nil, fmt.Errorf("解析topstories失败: %w", err)
+	}
+
+	if len(ids) > maxItems {
+		ids = ids[:maxItems]
+	}
+
+	items, err := f.fetchItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []models.Story
+	for _, item := range items {
+		if item == nil || item.Deleted || item.Dead {
+			continue
+		}
+		stories = append(stories, itemToStory(*item))
+	}
+	return stories, nil
+}
+
+// topStoriesFromAlgolia 用Algolia search_by_date接口按日期查询front_page标签的文章
+func (f *FirebaseHNClient) topStoriesFromAlgolia(ctx context.Context, date string, maxItems int) ([]models.Story, error) {
+	dayStart, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("解析日期失败: %w", err)
+	}
+	dayEnd := dayStart.Add(24 * time.Hour)
+
+	searchURL := fmt.Sprintf(
+		"%s/search_by_date?tags=front_page&numericFilters=created_at_i>%d,created_at_i<%d&hitsPerPage=%d",
+		algoliaBaseURL, dayStart.Unix(), dayEnd.Unix(), maxItems,
+	)
+
+	result, err := f.fetcher.Fetch(ctx, searchURL)
+	if err != nil {
+		return nil, fmt.Errorf("查询Algolia front_page失败: %w", err)
+	}
+
+	var resp algoliaSearchResponse
+	if err := json.Unmarshal(result.Body, &resp); err != nil {
+		return nil, fmt.Errorf("解析Algolia响应失败: %w", err)
+	}
+
+	var stories []models.Story
+	for _, hit := range resp.Hits {
+		if len(stories) >= maxItems {
+			break
+		}
+		stories = append(stories, models.Story{
+			ID:            hit.ObjectID,
+			Title:         hit.Title,
+			URL:           hit.URL,
+			HackerNewsURL: fmt.Sprintf("https://news.ycombinator.com/item?id=%s", hit.ObjectID),
+		})
+	}
+	return stories, nil
+}
+
+// GetStoryContent 获取文章的item详情（标题/正文链接）和评论树，
+// 渲染成带楼层缩进、深度受限的文本视图，供LLM提示词使用
+func (f *FirebaseHNClient) GetStoryContent(story models.Story, maxTokens int) (string, error) {
+	ctx := context.Background()
+
+	item, err := f.fetchItem(ctx, story.ID)
+	if err != nil {
+		return "", fmt.Errorf("获取故事详情失败: %w", err)
+	}
+
+	comments, err := f.fetchCommentTree(ctx, item.Kids, 0)
+	if err != nil {
+		return "", fmt.Errorf("获取评论失败: %w", err)
+	}
+
+	var result []string
+	if story.Title != "" {
+		result = append(result, fmt.Sprintf("<title>\n%s\n</title>", story.Title))
+	}
+
+	if item.Text != "" {
+		article := item.Text
+		if len(article) > maxTokens*4 {
+			article = article[:maxTokens*4]
+		}
+		result = append(result, fmt.Sprintf("<article>\n%s\n</article>", article))
+	}
+
+	rendered := renderComments(comments, maxCommentsPerStory)
+	if rendered != "" {
+		if len(rendered) > maxTokens*4 {
+			rendered = rendered[:maxTokens*4]
+		}
+		result = append(result, fmt.Sprintf("<comments>\n%s\n</comments>", rendered))
+	}
+
+	return strings.Join(result, "\n\n---\n\n"), nil
+}
+
+// fetchCommentTree 按ID列表递归拉取评论及其子评论，depth达到maxCommentDepth后停止下钻
+func (f *FirebaseHNClient) fetchCommentTree(ctx context.Context, ids []int, depth int) ([]models.Comment, error) {
+	if depth > maxCommentDepth || len(ids) == 0 {
+		return nil, nil
+	}
+
+	items, err := f.fetchItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	for _, item := range items {
+		if item == nil || item.Deleted || item.Dead || item.Text == "" {
+			continue
+		}
+
+		kids, err := f.fetchCommentTree(ctx, item.Kids, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, models.Comment{
+			Author: item.By,
+			Text:   item.Text,
+			Score:  item.Score,
+			Depth:  depth,
+			Kids:   kids,
+		})
+	}
+	return comments, nil
+}
+
+// fetchItem 获取单个item，优先走缓存
+func (f *FirebaseHNClient) fetchItem(ctx context.Context, id string) (*firebaseItem, error) {
+	key := "hn-item:" + id
+	if f.cache != nil {
+		if val, ok := f.cache.Get(ctx, key); ok {
+			var item firebaseItem
+			if err := json.Unmarshal(val, &item); err == nil {
+				return &item, nil
+			}
+		}
+	}
+
+	result, err := f.fetcher.Fetch(ctx, fmt.Sprintf("%s/item/%s.json", firebaseBaseURL, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var item firebaseItem
+	if err := json.Unmarshal(result.Body, &item); err != nil {
+		return nil, fmt.Errorf("解析item失败: %w", err)
+	}
+
+	if f.cache != nil {
+		if raw, err := json.Marshal(item); err == nil {
+			f.cache.Set(ctx, key, raw, f.cacheTTL)
+		}
+	}
+	return &item, nil
+}
+
+// fetchItems 并发拉取一批item，单个失败不影响其余结果（对应位置返回nil）
+func (f *FirebaseHNClient) fetchItems(ctx context.Context, ids []int) ([]*firebaseItem, error) {
+	items := make([]*firebaseItem, len(ids))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	failed := 0
+
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+
+			item, err := f.fetchItem(ctx, fmt.Sprintf("%d", id))
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				failed++
+				mu.Unlock()
+				return
+			}
+			items[i] = item
+		}(i, id)
+	}
+	wg.Wait()
+
+	// 只有整批全部失败时才返回错误
+	if failed > 0 && failed == len(ids) {
+		return nil, firstErr
+	}
+	return items, nil
+}
+
+// renderComments 把评论树渲染为带缩进的楼层文本，超过maxCount条后截断
+func renderComments(comments []models.Comment, maxCount int) string {
+	var sb strings.Builder
+	count := 0
+	renderCommentList(&sb, comments, maxCount, &count)
+	return strings.TrimSpace(sb.String())
+}
+
+func renderCommentList(sb *strings.Builder, comments []models.Comment, maxCount int, count *int) {
+	for _, c := range comments {
+		if *count >= maxCount {
+			return
+		}
+		indent := strings.Repeat("  ", c.Depth)
+		fmt.Fprintf(sb, "%s- [%s] %s\n", indent, c.Author, collapseWhitespace(c.Text))
+		*count++
+
+		renderCommentList(sb, c.Kids, maxCount, count)
+	}
+}
+
+// collapseWhitespace 把评论原始HTML里常见的换行/多余空白压成单行，便于渲染为一行楼层文本
+func collapseWhitespace(text string) string {
+	text = strings.ReplaceAll(text, "<p>", " ")
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// itemToStory 把Firebase的item转换为通用的models.Story
+func itemToStory(item firebaseItem) models.Story {
+	url := item.URL
+	if url == "" {
+		// 无外链的Ask HN/Show HN等帖子，指向HN自身的讨论页
+		url = fmt.Sprintf("https://news.ycombinator.com/item?id=%d", item.ID)
+	}
+
+	return models.Story{
+		ID:            fmt.Sprintf("%d", item.ID),
+		Title:         item.Title,
+		URL:           url,
+		HackerNewsURL: fmt.Sprintf("https://news.ycombinator.com/item?id=%d", item.ID),
+	}
+}
@@ -1,69 +1,71 @@
 package crawler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hacker-news/internal/cache"
 	"hacker-news/internal/models"
-	"io"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/sync/errgroup"
 )
 
-// HackerNewsClient 用于与Hacker News交互的客户端
-type HackerNewsClient struct {
-	jinaKey string
+// storyWorkerPoolSize 限制同时抓取多篇文章内容时的并发数，
+// 与Fetcher自身的并发上限配合，避免对目标站点造成突发压力
+const storyWorkerPoolSize = 8
+
+// HTMLHNClient 用于与Hacker News交互的客户端
+type HTMLHNClient struct {
+	jinaKey    string
+	cache      cache.Cache
+	cacheTTL   time.Duration
+	extractors []ArticleExtractor
+	diskCache  *diskArticleCache
+	fetcher    *Fetcher
 }
 
-// NewHackerNewsClient 创建一个新的HackerNews客户端
-func NewHackerNewsClient(jinaKey string) *HackerNewsClient {
-	return &HackerNewsClient{
-		jinaKey: jinaKey,
+// NewHTMLHNClient 创建一个新的HackerNews客户端，c为nil时不启用内存缓存，
+// articleCacheDir为空时不启用磁盘缓存。提取器按顺序尝试：PDF -> Readability -> Jina兜底
+func NewHTMLHNClient(jinaKey string, c cache.Cache, cacheTTL time.Duration, articleCacheDir string) *HTMLHNClient {
+	return &HTMLHNClient{
+		jinaKey:  jinaKey,
+		cache:    c,
+		cacheTTL: cacheTTL,
+		extractors: []ArticleExtractor{
+			NewPDFExtractor(),
+			NewReadabilityExtractor(),
+			NewJinaExtractor(jinaKey),
+		},
+		diskCache: newDiskArticleCache(articleCacheDir),
+		fetcher:   NewFetcher(storyWorkerPoolSize),
 	}
 }
 
 // GetTopStories 获取指定日期的热门文章
-func (c *HackerNewsClient) GetTopStories(date string, maxItems int) ([]models.Story, error) {
+func (c *HTMLHNClient) GetTopStories(date string, maxItems int) ([]models.Story, error) {
+	ctx := context.Background()
+
 	// 如果未指定日期，使用今天的日期
 	if date == "" {
 		date = time.Now().Format("2006-01-02")
 	}
 
 	// 构建请求URL - 直接访问Hacker News，不使用Jina代理
-	url := fmt.Sprintf("https://news.ycombinator.com/front?day=%s", date)
-	log.Printf("获取热门文章 %s 从 %s", date, url)
-
-	// 直接发送HTTP请求，不使用Jina代理
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
-	}
-
-	// 设置请求头 - 模拟浏览器请求
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	frontURL := fmt.Sprintf("https://news.ycombinator.com/front?day=%s", date)
+	log.Printf("获取热门文章 %s 从 %s", date, frontURL)
 
-	// 发送请求
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	result, err := c.fetcher.Fetch(ctx, frontURL)
 	if err != nil {
 		return nil, fmt.Errorf("获取热门文章失败: %w", err)
 	}
-	defer resp.Body.Close()
-
-	log.Printf("获取热门文章结果: %s", resp.Status)
-
-	// 读取响应
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
-	}
 
 	// 解析HTML
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(result.Body)))
 	if err != nil {
 		return nil, fmt.Errorf("解析HTML失败: %w", err)
 	}
@@ -73,14 +75,14 @@ func (c *HackerNewsClient) GetTopStories(date string, maxItems int) ([]models.St
 	doc.Find(".athing").Each(func(i int, s *goquery.Selection) {
 		id, _ := s.Attr("id")
 		title := s.Find(".titleline > a").Text()
-		url, _ := s.Find(".titleline > a").Attr("href")
+		storyURL, _ := s.Find(".titleline > a").Attr("href")
 		hackerNewsURL := fmt.Sprintf("https://news.ycombinator.com/item?id=%s", id)
 
-		if id != "" && url != "" {
+		if id != "" && storyURL != "" {
 			stories = append(stories, models.Story{
 				ID:            id,
 				Title:         title,
-				URL:           url,
+				URL:           storyURL,
 				HackerNewsURL: hackerNewsURL,
 			})
 		}
@@ -95,44 +97,34 @@ func (c *HackerNewsClient) GetTopStories(date string, maxItems int) ([]models.St
 	return stories, nil
 }
 
-// GetStoryContent 获取文章内容和评论
-func (c *HackerNewsClient) GetStoryContent(story models.Story, maxTokens int) (string, error) {
-	// 获取文章内容和评论
-	articleCh := make(chan string, 1)
-	commentsCh := make(chan string, 1)
-	errCh := make(chan error, 2)
+// GetStoryContent 获取文章内容和评论，两者通过errgroup并发抓取，
+// 任一个失败都会让整体调用返回错误（而不是静默吞掉后返回空内容）
+func (c *HTMLHNClient) GetStoryContent(story models.Story, maxTokens int) (string, error) {
+	ctx := context.Background()
 
-	// 并行获取文章和评论
-	go func() {
-		article, err := c.fetchArticle(story.URL)
+	var article, comments string
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		a, err := c.fetchArticle(gCtx, story.URL)
 		if err != nil {
-			errCh <- fmt.Errorf("获取文章失败: %w", err)
-			articleCh <- ""
-			return
+			return fmt.Errorf("获取文章失败: %w", err)
 		}
-		articleCh <- article
-	}()
+		article = a
+		return nil
+	})
 
-	go func() {
-		comments, err := c.fetchComments(story.ID)
+	g.Go(func() error {
+		cm, err := c.fetchComments(gCtx, story.ID)
 		if err != nil {
-			errCh <- fmt.Errorf("获取评论失败: %w", err)
-			commentsCh <- ""
-			return
+			return fmt.Errorf("获取评论失败: %w", err)
 		}
-		commentsCh <- comments
-	}()
-
-	// 获取结果
-	article := <-articleCh
-	comments := <-commentsCh
+		comments = cm
+		return nil
+	})
 
-	// 检查是否有错误
-	select {
-	case err := <-errCh:
+	if err := g.Wait(); err != nil {
 		return "", err
-	default:
-		// 继续处理
 	}
 
 	// 构建结果
@@ -164,78 +156,95 @@ func (c *HackerNewsClient) GetStoryContent(story models.Story, maxTokens int) (s
 	return strings.Join(result, "\n\n---\n\n"), nil
 }
 
-// fetchArticle 获取文章内容
-func (c *HackerNewsClient) fetchArticle(url string) (string, error) {
-	// 直接访问URL，不使用Jina代理
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("创建请求失败: %w", err)
+// fetchArticle 获取文章内容，优先从内存缓存、再从磁盘缓存读取，
+// 避免重复抓取和重复提取同一篇文章
+func (c *HTMLHNClient) fetchArticle(ctx context.Context, url string) (string, error) {
+	key := c.articleCacheKey(url)
+	if c.cache != nil {
+		if val, ok := c.cache.Get(ctx, key); ok {
+			return string(val), nil
+		}
 	}
 
-	// 设置请求头 - 模拟浏览器请求
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	if cached, ok := c.diskCache.get(url); ok {
+		if c.cache != nil {
+			c.cache.Set(ctx, key, []byte(cached), c.cacheTTL)
+		}
+		return cached, nil
+	}
 
-	// 发送请求
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	article, err := c.doFetchArticle(ctx, url)
 	if err != nil {
-		log.Printf("获取文章失败: %v %s", err, url)
-		return "", nil
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("获取文章失败: %s %s", resp.Status, url)
-		return "", nil
+	if article != "" {
+		if c.cache != nil {
+			c.cache.Set(ctx, key, []byte(article), c.cacheTTL)
+		}
+		c.diskCache.set(url, article)
 	}
+	return article, nil
+}
 
-	// 读取响应
-	body, err := io.ReadAll(resp.Body)
+// articleCacheKey 以文章URL的SHA-256摘要作为缓存键
+func (c *HTMLHNClient) articleCacheKey(url string) string {
+	h := sha256.Sum256([]byte(url))
+	return "article:" + hex.EncodeToString(h[:])
+}
+
+// doFetchArticle 抓取文章原始响应，再交给匹配的ArticleExtractor提取正文，
+// 避免把整页HTML（含导航/广告/脚本）原样塞进LLM提示词
+func (c *HTMLHNClient) doFetchArticle(ctx context.Context, articleURL string) (string, error) {
+	result, err := c.fetcher.Fetch(ctx, articleURL)
 	if err != nil {
-		return "", fmt.Errorf("读取响应失败: %w", err)
+		return "", fmt.Errorf("获取文章失败: %w", err)
 	}
 
-	return string(body), nil
+	return c.extractArticle(ctx, articleURL, result.ContentType, result.Body)
 }
 
-// fetchComments 获取文章评论
-func (c *HackerNewsClient) fetchComments(storyID string) (string, error) {
-	// 直接访问Hacker News评论页面
-	commentURL := fmt.Sprintf("https://news.ycombinator.com/item?id=%s", storyID)
-	req, err := http.NewRequest("GET", commentURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("创建请求失败: %w", err)
+// extractArticle 按Content-Type和站点特征挑选合适的ArticleExtractor，
+// 已知的JS渲染站点（如Twitter/Medium）直接走Jina兜底，其余按提取器注册顺序尝试
+func (c *HTMLHNClient) extractArticle(ctx context.Context, articleURL string, contentType string, body []byte) (string, error) {
+	if isKnownSPAHost(articleURL) {
+		for _, e := range c.extractors {
+			if jina, ok := e.(*JinaExtractor); ok && jina.CanHandle(articleURL, contentType) {
+				return jina.Extract(ctx, articleURL, contentType, body)
+			}
+		}
 	}
 
-	// 设置请求头 - 模拟浏览器请求
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	for _, e := range c.extractors {
+		if !e.CanHandle(articleURL, contentType) {
+			continue
+		}
 
-	// 发送请求
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("获取评论失败: %v https://news.ycombinator.com/item?id=%s", err, storyID)
-		return "", nil
+		content, err := e.Extract(ctx, articleURL, contentType, body)
+		if err != nil {
+			log.Printf("提取文章正文失败，尝试下一个提取器: %v %s", err, articleURL)
+			continue
+		}
+		if strings.TrimSpace(content) != "" {
+			return content, nil
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("获取评论失败: %s https://news.ycombinator.com/item?id=%s", resp.Status, storyID)
-		return "", nil
-	}
+	return string(body), nil
+}
+
+// fetchComments 获取文章评论
+func (c *HTMLHNClient) fetchComments(ctx context.Context, storyID string) (string, error) {
+	// 直接访问Hacker News评论页面
+	commentURL := fmt.Sprintf("https://news.ycombinator.com/item?id=%s", storyID)
 
-	// 读取响应
-	body, err := io.ReadAll(resp.Body)
+	result, err := c.fetcher.Fetch(ctx, commentURL)
 	if err != nil {
-		return "", fmt.Errorf("读取响应失败: %w", err)
+		return "", fmt.Errorf("获取评论失败: %w", err)
 	}
 
 	// 使用goquery提取评论内容，模拟Jina的X-Target-Selector功能
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(result.Body)))
 	if err != nil {
 		return "", fmt.Errorf("解析HTML失败: %w", err)
 	}
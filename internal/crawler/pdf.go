@@ -0,0 +1,37 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// extractPDFText 逐页读取PDF纯文本并用空行拼接，供PDFExtractor使用
+func extractPDFText(data []byte) (string, error) {
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("打开PDF失败: %w", err)
+	}
+
+	var pages []string
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		text, err := page.GetPlainText(nil)
+		if err != nil {
+			continue
+		}
+
+		text = strings.TrimSpace(text)
+		if text != "" {
+			pages = append(pages, text)
+		}
+	}
+
+	return strings.Join(pages, "\n\n"), nil
+}
@@ -0,0 +1,14 @@
+package crawler
+
+import "hacker-news/internal/models"
+
+// NewsSource 是获取Hacker News故事列表及其正文/评论的统一接口，
+// HTMLHNClient（抓取网页HTML）和FirebaseHNClient（官方Firebase API）都实现该接口，
+// 上层只依赖NewsSource，通过配置切换具体实现
+type NewsSource interface {
+	// GetTopStories 获取指定日期的热门文章
+	GetTopStories(date string, maxItems int) ([]models.Story, error)
+
+	// GetStoryContent 获取文章内容和评论，渲染为适合塞进LLM提示词的文本
+	GetStoryContent(story models.Story, maxTokens int) (string, error)
+}
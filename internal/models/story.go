@@ -10,6 +10,16 @@ type Story struct {
 	Comments     string `json:"comments,omitempty"`
 }
 
+// Comment 表示一条Hacker News评论，Kids是按楼层嵌套的子评论，
+// Depth从0开始表示嵌套层级，用于渲染带缩进的楼层视图
+type Comment struct {
+	Author string    `json:"author"`
+	Text   string    `json:"text"`
+	Score  int       `json:"score,omitempty"`
+	Depth  int       `json:"depth"`
+	Kids   []Comment `json:"kids,omitempty"`
+}
+
 // StoryContent 表示文章内容（包括原文和评论）
 type StoryContent struct {
 	Title    string `json:"title"`
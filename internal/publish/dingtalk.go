@@ -0,0 +1,147 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hacker-news/config"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DingTalkPublisher 把播客/博客发布为钉钉群机器人的markdown消息卡片，
+// 支持同时配置多个机器人（扇出到多个群）
+type DingTalkPublisher struct {
+	bots []config.DingTalkBot
+}
+
+// NewDingTalkPublisher 创建一个新的钉钉机器人发布器
+func NewDingTalkPublisher(cfg config.DingTalkPublishConfig) *DingTalkPublisher {
+	return &DingTalkPublisher{bots: cfg.Bots}
+}
+
+// Channel 返回渠道名称
+func (d *DingTalkPublisher) Channel() string {
+	return "dingtalk"
+}
+
+// PublishPodcast 把播客简介和音频链接渲染成markdown卡片，发给每个配置的机器人
+func (d *DingTalkPublisher) PublishPodcast(ctx context.Context, info PodcastInfo) error {
+	title := fmt.Sprintf("%s - %s", info.Title, info.Date)
+	text := fmt.Sprintf("### %s\n\n%s\n\n[点击收听本期播客](%s)", title, info.Summary, info.AudioURL)
+	return d.broadcast(ctx, title, text)
+}
+
+// PublishBlog 把博客内容渲染成markdown卡片，发给每个配置的机器人
+func (d *DingTalkPublisher) PublishBlog(ctx context.Context, info BlogInfo) error {
+	title := fmt.Sprintf("%s - %s", info.Title, info.Date)
+	text := fmt.Sprintf("### %s\n\n%s", title, info.Content)
+	return d.broadcast(ctx, title, text)
+}
+
+// broadcast 对所有配置的机器人扇出发送，任一机器人失败不影响其余机器人，
+// 所有机器人都失败时返回汇总错误
+func (d *DingTalkPublisher) broadcast(ctx context.Context, title, text string) error {
+	if len(d.bots) == 0 {
+		return fmt.Errorf("未配置任何钉钉机器人")
+	}
+
+	var errs []error
+	for _, bot := range d.bots {
+		if err := d.sendOne(ctx, bot, title, text); err != nil {
+			log.Printf("钉钉机器人%s发送失败: %v", bot.Name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", bot.Name, err))
+		}
+	}
+
+	if len(errs) == len(d.bots) {
+		return fmt.Errorf("所有钉钉机器人均发送失败: %v", errs)
+	}
+	return nil
+}
+
+// sendOne 向单个机器人的webhook发送markdown消息，配置了Secret时按钉钉的
+// HMAC-SHA256签名机制附带timestamp+sign查询参数
+func (d *DingTalkPublisher) sendOne(ctx context.Context, bot config.DingTalkBot, title, text string) error {
+	webhook := bot.Webhook
+	if bot.Secret != "" {
+		signed, err := d.signWebhook(webhook, bot.Secret)
+		if err != nil {
+			return fmt.Errorf("签名webhook失败: %w", err)
+		}
+		webhook = signed
+	}
+
+	message := map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"title": title,
+			"text":  text,
+		},
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("序列化消息失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("钉钉接口返回错误: %d %s", result.ErrCode, result.ErrMsg)
+	}
+
+	return nil
+}
+
+// signWebhook 按钉钉自定义机器人的签名规则，把timestamp和sign追加到webhook URL上：
+// sign = base64(hmac_sha256(secret, "timestamp\nsecret"))
+func (d *DingTalkPublisher) signWebhook(webhook, secret string) (string, error) {
+	timestamp := time.Now().UnixMilli()
+	stringToSign := fmt.Sprintf("%d\n%s", timestamp, secret)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return "", fmt.Errorf("解析webhook URL失败: %w", err)
+	}
+	q := u.Query()
+	q.Set("timestamp", fmt.Sprintf("%d", timestamp))
+	q.Set("sign", sign)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,69 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"hacker-news/config"
+	"log"
+)
+
+// BuildPublishers 根据cfg.Publish.Channels构建启用的Publisher列表，
+// 渠道名不认识时跳过并记录警告，不中断启动流程
+func BuildPublishers(cfg config.PublishConfig) []Publisher {
+	var publishers []Publisher
+
+	for _, channel := range cfg.Channels {
+		switch channel {
+		case "wechat":
+			publishers = append(publishers, NewWeChatPublisher(cfg.WeChat))
+		case "dingtalk":
+			publishers = append(publishers, NewDingTalkPublisher(cfg.DingTalk))
+		default:
+			log.Printf("警告: 未知的分发渠道: %s", channel)
+		}
+	}
+
+	return publishers
+}
+
+// MultiPublisher 把同一次发布请求扇出到多个已启用的渠道
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+// NewMultiPublisher 创建一个聚合多个渠道的发布器
+func NewMultiPublisher(publishers []Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+// PublishPodcast 对所有启用的渠道发布播客，单个渠道失败不影响其余渠道，
+// 所有渠道都失败时返回汇总错误
+func (m *MultiPublisher) PublishPodcast(ctx context.Context, info PodcastInfo) error {
+	var errs []error
+	for _, p := range m.publishers {
+		if err := p.PublishPodcast(ctx, info); err != nil {
+			log.Printf("渠道%s发布播客失败: %v", p.Channel(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", p.Channel(), err))
+		}
+	}
+	if len(m.publishers) > 0 && len(errs) == len(m.publishers) {
+		return fmt.Errorf("所有渠道均发布失败: %v", errs)
+	}
+	return nil
+}
+
+// PublishBlog 对所有启用的渠道发布博客，单个渠道失败不影响其余渠道，
+// 所有渠道都失败时返回汇总错误
+func (m *MultiPublisher) PublishBlog(ctx context.Context, info BlogInfo) error {
+	var errs []error
+	for _, p := range m.publishers {
+		if err := p.PublishBlog(ctx, info); err != nil {
+			log.Printf("渠道%s发布博客失败: %v", p.Channel(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", p.Channel(), err))
+		}
+	}
+	if len(m.publishers) > 0 && len(errs) == len(m.publishers) {
+		return fmt.Errorf("所有渠道均发布失败: %v", errs)
+	}
+	return nil
+}
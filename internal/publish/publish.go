@@ -0,0 +1,32 @@
+// Package publish 负责把每日生成的播客/博客内容分发到外部渠道
+// （微信公众号、钉钉机器人等），各渠道实现同一个Publisher接口
+package publish
+
+import "context"
+
+// PodcastInfo 是分发一期播客所需的信息
+type PodcastInfo struct {
+	Date     string // 日期，格式YYYY-MM-DD
+	Title    string
+	Summary  string // 简介/摘要，用于文章正文或消息卡片
+	AudioURL string // 音频的可访问URL（预签名或公开URL）
+}
+
+// BlogInfo 是分发一期博客所需的信息
+type BlogInfo struct {
+	Date    string
+	Title   string
+	Content string
+}
+
+// Publisher 定义发布到单个渠道的能力
+type Publisher interface {
+	// PublishPodcast 发布一期播客
+	PublishPodcast(ctx context.Context, info PodcastInfo) error
+
+	// PublishBlog 发布一期博客
+	PublishBlog(ctx context.Context, info BlogInfo) error
+
+	// Channel 返回渠道名称，用于日志和错误归因
+	Channel() string
+}
@@ -0,0 +1,284 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hacker-news/config"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// WeChatPublisher 把播客/博客发布为微信公众号图文素材，
+// 支持同时配置多个公众号（多组AppID/AppSecret），发布时对所有账号扇出
+type WeChatPublisher struct {
+	credentials []config.WeChatCredential
+}
+
+// NewWeChatPublisher 创建一个新的微信公众号发布器
+func NewWeChatPublisher(cfg config.WeChatPublishConfig) *WeChatPublisher {
+	return &WeChatPublisher{credentials: cfg.Credentials}
+}
+
+// Channel 返回渠道名称
+func (w *WeChatPublisher) Channel() string {
+	return "wechat"
+}
+
+// PublishPodcast 把音频下载后上传为微信永久语音素材，再把引用该素材的图文消息
+// 上传到每个配置的公众号。info.AudioURL只是预签名URL，7天后失效，
+// 永久语音素材让图文消息引用的音频不受限
+func (w *WeChatPublisher) PublishPodcast(ctx context.Context, info PodcastInfo) error {
+	if len(w.credentials) == 0 {
+		return fmt.Errorf("未配置任何微信公众号凭证")
+	}
+
+	audioData, err := w.fetchAudio(ctx, info.AudioURL)
+	if err != nil {
+		return fmt.Errorf("下载播客音频失败: %w", err)
+	}
+
+	var errs []error
+	for _, cred := range w.credentials {
+		if err := w.publishPodcastOne(ctx, cred, info, audioData); err != nil {
+			log.Printf("公众号%s发布播客失败: %v", cred.ClientID, err)
+			errs = append(errs, fmt.Errorf("%s: %w", cred.ClientID, err))
+		}
+	}
+
+	if len(errs) == len(w.credentials) {
+		return fmt.Errorf("所有公众号均发布失败: %v", errs)
+	}
+	return nil
+}
+
+// publishPodcastOne 为单个公众号账号：获取access_token -> 把音频上传为永久语音素材
+// -> 发布引用该素材media_id的图文消息
+func (w *WeChatPublisher) publishPodcastOne(ctx context.Context, cred config.WeChatCredential, info PodcastInfo, audioData []byte) error {
+	token, err := w.fetchAccessToken(ctx, cred)
+	if err != nil {
+		return fmt.Errorf("获取access_token失败: %w", err)
+	}
+
+	voiceMediaID, err := w.uploadVoiceMaterial(ctx, token, audioData)
+	if err != nil {
+		return fmt.Errorf("上传语音素材失败: %w", err)
+	}
+	log.Printf("公众号%s播客语音素材上传成功，media_id: %s", cred.ClientID, voiceMediaID)
+
+	content := fmt.Sprintf(
+		"<p>%s</p><p>语音素材media_id: %s</p><p><a href=\"%s\">点击收听本期播客</a></p>",
+		info.Summary, voiceMediaID, info.AudioURL,
+	)
+
+	return w.publishArticle(ctx, token, info.Title, info.Summary, content)
+}
+
+// fetchAudio 下载播客音频的完整字节内容，供上传为微信永久语音素材
+func (w *WeChatPublisher) fetchAudio(ctx context.Context, audioURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, audioURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("下载音频返回非200状态码: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取音频数据失败: %w", err)
+	}
+	return data, nil
+}
+
+// uploadVoiceMaterial 把音频上传为微信永久素材（type=voice），返回素材media_id
+func (w *WeChatPublisher) uploadVoiceMaterial(ctx context.Context, token string, audioData []byte) (string, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	part, err := mw.CreateFormFile("media", "podcast.mp3")
+	if err != nil {
+		return "", fmt.Errorf("创建表单失败: %w", err)
+	}
+	if _, err := part.Write(audioData); err != nil {
+		return "", fmt.Errorf("写入音频数据失败: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return "", fmt.Errorf("关闭表单失败: %w", err)
+	}
+
+	uploadURL := fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/material/add_material?access_token=%s&type=voice", token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, &body)
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		MediaId string `json:"media_id"`
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.MediaId == "" {
+		return "", fmt.Errorf("微信接口返回错误: %d %s", result.ErrCode, result.ErrMsg)
+	}
+
+	return result.MediaId, nil
+}
+
+// PublishBlog 把博客正文包装成图文消息，上传到每个配置的公众号
+func (w *WeChatPublisher) PublishBlog(ctx context.Context, info BlogInfo) error {
+	return w.publishNews(ctx, info.Title, info.Title, info.Content)
+}
+
+// publishNews 对所有配置的公众号账号扇出发布，任一账号失败不影响其余账号，
+// 所有账号都失败时返回汇总错误
+func (w *WeChatPublisher) publishNews(ctx context.Context, title, digest, content string) error {
+	if len(w.credentials) == 0 {
+		return fmt.Errorf("未配置任何微信公众号凭证")
+	}
+
+	var errs []error
+	for _, cred := range w.credentials {
+		if err := w.publishOne(ctx, cred, title, digest, content); err != nil {
+			log.Printf("公众号%s发布失败: %v", cred.ClientID, err)
+			errs = append(errs, fmt.Errorf("%s: %w", cred.ClientID, err))
+		}
+	}
+
+	if len(errs) == len(w.credentials) {
+		return fmt.Errorf("所有公众号均发布失败: %v", errs)
+	}
+	return nil
+}
+
+// publishOne 为单个公众号账号：获取access_token -> 上传永久图文素材
+func (w *WeChatPublisher) publishOne(ctx context.Context, cred config.WeChatCredential, title, digest, content string) error {
+	token, err := w.fetchAccessToken(ctx, cred)
+	if err != nil {
+		return fmt.Errorf("获取access_token失败: %w", err)
+	}
+
+	return w.publishArticle(ctx, token, title, digest, content)
+}
+
+// publishArticle 用已获取的access_token把图文消息上传为永久素材
+func (w *WeChatPublisher) publishArticle(ctx context.Context, token, title, digest, content string) error {
+	article := map[string]interface{}{
+		"articles": []map[string]interface{}{
+			{
+				"title":              title,
+				"author":             "Hacker News 播报",
+				"digest":             digest,
+				"content":            content,
+				"content_source_url": "",
+				"show_cover_pic":     0,
+			},
+		},
+	}
+
+	payload, err := json.Marshal(article)
+	if err != nil {
+		return fmt.Errorf("序列化图文素材失败: %w", err)
+	}
+
+	uploadURL := fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/material/add_news?access_token=%s", token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		MediaId string `json:"media_id"`
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("微信接口返回错误: %d %s", result.ErrCode, result.ErrMsg)
+	}
+
+	log.Printf("微信公众号发布成功，media_id: %s", result.MediaId)
+	return nil
+}
+
+// fetchAccessToken 用AppID/AppSecret换取接口调用凭证
+func (w *WeChatPublisher) fetchAccessToken(ctx context.Context, cred config.WeChatCredential) (string, error) {
+	tokenURL := fmt.Sprintf(
+		"https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=%s&secret=%s",
+		cred.ClientID, cred.ClientSecret,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ErrCode     int    `json:"errcode"`
+		ErrMsg      string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("微信接口返回错误: %d %s", result.ErrCode, result.ErrMsg)
+	}
+
+	return result.AccessToken, nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// Backend 是对象存储后端的统一接口。
+// MinIO、S3、阿里云OSS与本地文件系统驱动都实现该接口，
+// 上层代码只依赖Backend，不关心具体用的是哪种存储
+type Backend interface {
+	// UploadFile 上传数据并返回可直接访问的URL（通常是预签名URL）
+	UploadFile(ctx context.Context, objectName string, data []byte, contentType string) (string, error)
+
+	// DownloadFile 下载指定对象的完整内容
+	DownloadFile(ctx context.Context, objectName string) ([]byte, error)
+
+	// DeleteFile 删除指定对象
+	DeleteFile(ctx context.Context, objectName string) error
+
+	// ListFiles 列出指定前缀下的所有对象键
+	ListFiles(ctx context.Context, prefix string) ([]string, error)
+
+	// ObjectExists 检查对象是否存在
+	ObjectExists(ctx context.Context, objectName string) (bool, error)
+
+	// StatFile 获取对象的字节大小，不下载对象内容
+	StatFile(ctx context.Context, objectName string) (int64, error)
+
+	// GetPresignedURL 生成一个带有效期的可访问URL
+	GetPresignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,21 @@
+package storage
+
+import "hacker-news/config"
+
+// Factory 根据配置创建对象存储后端
+func Factory(cfg *config.StorageConfig) (Backend, error) {
+	// 根据配置选择存储后端
+	switch cfg.Provider {
+	case "s3":
+		return NewS3Client(&cfg.S3)
+	case "oss":
+		return NewOSSClient(&cfg.OSS)
+	case "local":
+		return NewLocalClient(&cfg.Local)
+	case "minio":
+		return NewMinioClient(&cfg.MinIO)
+	default:
+		// 默认使用MinIO
+		return NewMinioClient(&cfg.MinIO)
+	}
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hacker-news/config"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalClient 是本地文件系统存储的封装，实现Backend接口，
+// 供没有对象存储服务的开发/CI环境使用。
+// 预签名URL通过HMAC签名加过期时间戳实现，由API服务器的/storage路由校验后回源文件系统
+type LocalClient struct {
+	baseDir string
+	baseURL string
+	signKey string
+}
+
+// NewLocalClient 创建一个新的本地文件系统存储客户端
+func NewLocalClient(cfg *config.LocalStorageConfig) (*LocalClient, error) {
+	if err := os.MkdirAll(cfg.BaseDir, 0755); err != nil {
+		return nil, fmt.Errorf("创建本地存储目录失败: %w", err)
+	}
+
+	return &LocalClient{
+		baseDir: cfg.BaseDir,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		signKey: cfg.SignKey,
+	}, nil
+}
+
+// resolvePath 把对象键映射到本地文件路径，clean之后防止目录穿越
+func (c *LocalClient) resolvePath(objectName string) string {
+	cleaned := filepath.Clean("/" + objectName)
+	return filepath.Join(c.baseDir, cleaned)
+}
+
+// UploadFile 把数据写入本地文件系统，并返回带签名的访问URL
+func (c *LocalClient) UploadFile(ctx context.Context, objectName string, data []byte, contentType string) (string, error) {
+	path := c.resolvePath(objectName)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("创建目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("写入文件失败: %w", err)
+	}
+
+	log.Printf("文件 %s 写入本地存储成功，大小: %d", objectName, len(data))
+
+	return c.GetPresignedURL(ctx, objectName, 7*24*time.Hour) // 7天有效期
+}
+
+// DownloadFile 从本地文件系统读取文件
+func (c *LocalClient) DownloadFile(ctx context.Context, objectName string) ([]byte, error) {
+	data, err := os.ReadFile(c.resolvePath(objectName))
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteFile 删除本地文件
+func (c *LocalClient) DeleteFile(ctx context.Context, objectName string) error {
+	if err := os.Remove(c.resolvePath(objectName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除文件失败: %w", err)
+	}
+	return nil
+}
+
+// ListFiles 列出指定前缀的所有文件
+func (c *LocalClient) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	var objects []string
+	err := filepath.WalkDir(c.baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(c.baseDir, path)
+		if err != nil {
+			return err
+		}
+		objectName := filepath.ToSlash(rel)
+		if strings.HasPrefix(objectName, prefix) {
+			objects = append(objects, objectName)
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("列出文件失败: %w", err)
+	}
+
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// ObjectExists 检查文件是否存在
+func (c *LocalClient) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	if _, err := os.Stat(c.resolvePath(objectName)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("检查文件是否存在失败: %w", err)
+	}
+	return true, nil
+}
+
+// StatFile 获取文件的字节大小，不读取文件内容
+func (c *LocalClient) StatFile(ctx context.Context, objectName string) (int64, error) {
+	info, err := os.Stat(c.resolvePath(objectName))
+	if err != nil {
+		return 0, fmt.Errorf("获取文件信息失败: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// GetPresignedURL 生成一个带HMAC签名和过期时间的本地访问URL，
+// 由API服务器的/storage路由负责校验签名并回源本地文件系统
+func (c *LocalClient) GetPresignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
+	expires := time.Now().Add(expiry).Unix()
+
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("sig", c.sign(objectName, expires))
+
+	return fmt.Sprintf("%s/storage/%s?%s", c.baseURL, objectName, q.Encode()), nil
+}
+
+// sign 计算对象键+过期时间戳的HMAC-SHA256签名
+func (c *LocalClient) sign(objectName string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(c.signKey))
+	fmt.Fprintf(mac, "%s:%d", objectName, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignedURL 校验/storage路由收到的签名和有效期是否匹配，由API服务器在回源前调用
+func (c *LocalClient) VerifySignedURL(objectName string, expires int64, sig string) bool {
+	if time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(c.sign(objectName, expires)), []byte(sig))
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
-// MinioClient 是MinIO存储客户端的封装
+// MinioClient 是MinIO存储客户端的封装，实现Backend接口
 type MinioClient struct {
 	client     *minio.Client
 	bucketName string
@@ -148,6 +148,15 @@ func (c *MinioClient) ListFiles(ctx context.Context, prefix string) ([]string, e
 	return objects, nil
 }
 
+// StatFile 获取对象的字节大小，不下载对象内容
+func (c *MinioClient) StatFile(ctx context.Context, objectName string) (int64, error) {
+	info, err := c.client.StatObject(ctx, c.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("获取对象信息失败: %w", err)
+	}
+	return info.Size, nil
+}
+
 // ObjectExists 检查对象是否存在
 func (c *MinioClient) ObjectExists(ctx context.Context, objectName string) (bool, error) {
 	// 尝试使用GetObject方法获取对象头信息，如果成功则对象存在
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hacker-news/config"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// OSSClient 是阿里云OSS存储客户端的封装，实现Backend接口
+type OSSClient struct {
+	bucket     *oss.Bucket
+	bucketName string
+}
+
+// NewOSSClient 创建一个新的阿里云OSS客户端
+func NewOSSClient(cfg *config.OSSConfig) (*OSSClient, error) {
+	client, err := oss.New(cfg.Endpoint, cfg.AccessKeyID, cfg.AccessKeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("创建OSS客户端失败: %w", err)
+	}
+
+	// 确保bucket存在
+	exists, err := client.IsBucketExist(cfg.BucketName)
+	if err != nil {
+		return nil, fmt.Errorf("检查bucket是否存在失败: %w", err)
+	}
+	if !exists {
+		log.Printf("Bucket %s 不存在，正在创建...", cfg.BucketName)
+		if err := client.CreateBucket(cfg.BucketName); err != nil {
+			return nil, fmt.Errorf("创建bucket失败: %w", err)
+		}
+		log.Printf("Bucket %s 创建成功", cfg.BucketName)
+	}
+
+	bucket, err := client.Bucket(cfg.BucketName)
+	if err != nil {
+		return nil, fmt.Errorf("获取bucket失败: %w", err)
+	}
+
+	return &OSSClient{
+		bucket:     bucket,
+		bucketName: cfg.BucketName,
+	}, nil
+}
+
+// UploadFile 上传文件到OSS
+func (c *OSSClient) UploadFile(ctx context.Context, objectName string, data []byte, contentType string) (string, error) {
+	if err := c.bucket.PutObject(objectName, bytes.NewReader(data), oss.ContentType(contentType)); err != nil {
+		return "", fmt.Errorf("上传文件失败: %w", err)
+	}
+
+	log.Printf("文件 %s 上传成功，大小: %d", objectName, len(data))
+
+	presignedURL, err := c.GetPresignedURL(ctx, objectName, 7*24*time.Hour) // 7天有效期
+	if err != nil {
+		log.Printf("生成预签名URL失败: %v", err)
+		return fmt.Sprintf("/%s/%s", c.bucketName, objectName), nil
+	}
+
+	return presignedURL, nil
+}
+
+// DownloadFile 从OSS下载文件
+func (c *OSSClient) DownloadFile(ctx context.Context, objectName string) ([]byte, error) {
+	reader, err := c.bucket.GetObject(objectName)
+	if err != nil {
+		return nil, fmt.Errorf("获取对象失败: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取对象数据失败: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetPresignedURL 生成预签名URL
+func (c *OSSClient) GetPresignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
+	signedURL, err := c.bucket.SignURL(objectName, oss.HTTPGet, int64(expiry.Seconds()))
+	if err != nil {
+		return "", fmt.Errorf("生成预签名URL失败: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+// DeleteFile 从OSS删除文件
+func (c *OSSClient) DeleteFile(ctx context.Context, objectName string) error {
+	return c.bucket.DeleteObject(objectName)
+}
+
+// ListFiles 列出指定前缀的所有文件
+func (c *OSSClient) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	var objects []string
+	marker := ""
+
+	for {
+		result, err := c.bucket.ListObjects(oss.Prefix(prefix), oss.Marker(marker))
+		if err != nil {
+			return nil, fmt.Errorf("列出对象失败: %w", err)
+		}
+
+		for _, obj := range result.Objects {
+			objects = append(objects, obj.Key)
+		}
+
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return objects, nil
+}
+
+// ObjectExists 检查对象是否存在
+func (c *OSSClient) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	exists, err := c.bucket.IsObjectExist(objectName)
+	if err != nil {
+		return false, fmt.Errorf("检查对象是否存在失败: %w", err)
+	}
+	return exists, nil
+}
+
+// StatFile 获取对象的字节大小，不下载对象内容
+func (c *OSSClient) StatFile(ctx context.Context, objectName string) (int64, error) {
+	header, err := c.bucket.GetObjectMeta(objectName)
+	if err != nil {
+		return 0, fmt.Errorf("获取对象信息失败: %w", err)
+	}
+
+	size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("解析对象大小失败: %w", err)
+	}
+	return size, nil
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hacker-news/config"
+	"io"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Client 是AWS S3（或兼容S3协议的第三方服务）存储客户端的封装，实现Backend接口
+type S3Client struct {
+	client        *s3.Client
+	presignClient *s3.PresignClient
+	bucketName    string
+}
+
+// NewS3Client 创建一个新的S3客户端。Endpoint留空时使用AWS官方endpoint，
+// 填写时按path-style访问，便于对接自建/兼容S3协议的服务
+func NewS3Client(cfg *config.S3Config) (*S3Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(cfg.Region),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("加载AWS配置失败: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	// 确保bucket存在
+	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(cfg.BucketName)}); err != nil {
+		log.Printf("Bucket %s 不存在，正在创建...", cfg.BucketName)
+		if _, err := client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(cfg.BucketName)}); err != nil {
+			return nil, fmt.Errorf("创建bucket失败: %w", err)
+		}
+		log.Printf("Bucket %s 创建成功", cfg.BucketName)
+	}
+
+	return &S3Client{
+		client:        client,
+		presignClient: s3.NewPresignClient(client),
+		bucketName:    cfg.BucketName,
+	}, nil
+}
+
+// UploadFile 上传文件到S3
+func (c *S3Client) UploadFile(ctx context.Context, objectName string, data []byte, contentType string) (string, error) {
+	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(c.bucketName),
+		Key:         aws.String(objectName),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("上传文件失败: %w", err)
+	}
+
+	log.Printf("文件 %s 上传成功，大小: %d", objectName, len(data))
+
+	presignedURL, err := c.GetPresignedURL(ctx, objectName, 7*24*time.Hour) // 7天有效期
+	if err != nil {
+		log.Printf("生成预签名URL失败: %v", err)
+		return fmt.Sprintf("/%s/%s", c.bucketName, objectName), nil
+	}
+
+	return presignedURL, nil
+}
+
+// DownloadFile 从S3下载文件
+func (c *S3Client) DownloadFile(ctx context.Context, objectName string) ([]byte, error) {
+	obj, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取对象失败: %w", err)
+	}
+	defer obj.Body.Close()
+
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取对象数据失败: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetPresignedURL 生成预签名URL
+func (c *S3Client) GetPresignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
+	req, err := c.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectName),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("生成预签名URL失败: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// DeleteFile 从S3删除文件
+func (c *S3Client) DeleteFile(ctx context.Context, objectName string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectName),
+	})
+	return err
+}
+
+// ListFiles 列出指定前缀的所有文件
+func (c *S3Client) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	var objects []string
+
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucketName),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("列出对象失败: %w", err)
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, aws.ToString(obj.Key))
+		}
+	}
+
+	return objects, nil
+}
+
+// ObjectExists 检查对象是否存在
+func (c *S3Client) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	_, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectName),
+	})
+	if err != nil {
+		// HeadObject在对象不存在时返回404错误，这里统一当作不存在处理
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// StatFile 获取对象的字节大小，不下载对象内容
+func (c *S3Client) StatFile(ctx context.Context, objectName string) (int64, error) {
+	out, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectName),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("获取对象信息失败: %w", err)
+	}
+	return aws.ToInt64(out.ContentLength), nil
+}
@@ -29,25 +29,29 @@ func NewAliyunTTS(cfg config.AliyunTTSConfig) (*AliyunTTS, error) {
 	}, nil
 }
 
-// SynthesizeSpeech 将文本转换为语音
-func (a *AliyunTTS) SynthesizeSpeech(ctx context.Context, text string, speaker string) ([]byte, error) {
-	// 根据角色获取语音ID
-	voiceID, err := GetSpeakerVoice("aliyun", speaker)
+// Synthesize 按SynthesisRequest合成语音
+func (a *AliyunTTS) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	voiceID, err := resolveVoice("aliyun", req)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Printf("使用阿里云TTS转换文本，语音ID: %s", voiceID)
 
+	volume := req.Volume
+	if volume == 0 {
+		volume = 50
+	}
+
 	// 构建请求参数
 	params := map[string]string{
 		"Action":           "SpeechSynthesis",
 		"Format":           "mp3",
 		"Voice":            voiceID,
-		"Volume":           "50",
-		"SpeechRate":       "0",
-		"PitchRate":        "0",
-		"Text":             text,
+		"Volume":           fmt.Sprintf("%d", int(volume)),
+		"SpeechRate":       fmt.Sprintf("%d", int(req.Rate)),
+		"PitchRate":        fmt.Sprintf("%d", int(req.Pitch)),
+		"Text":             req.Content(),
 		"Version":          "2019-08-10",
 		"RegionId":         a.config.Region,
 		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
@@ -66,21 +70,21 @@ func (a *AliyunTTS) SynthesizeSpeech(ctx context.Context, text string, speaker s
 
 	// 构建请求URL
 	requestURL := fmt.Sprintf("https://nls-gateway-%s.aliyuncs.com/", a.config.Region)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 
 	// 添加查询参数
-	q := req.URL.Query()
+	q := httpReq.URL.Query()
 	for k, v := range params {
 		q.Add(k, v)
 	}
-	req.URL.RawQuery = q.Encode()
+	httpReq.URL.RawQuery = q.Encode()
 
 	// 发送请求
 	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("发送请求失败: %w", err)
 	}
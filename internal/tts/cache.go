@@ -0,0 +1,64 @@
+package tts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hacker-news/internal/cache"
+	"time"
+)
+
+// cachedService 是TTS服务的缓存装饰器，按提供商+文本+角色的摘要缓存合成结果，
+// 避免对相同文本重复调用TTS接口
+type cachedService struct {
+	inner Service
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// WithCache 用缓存包装一个TTS服务
+func WithCache(inner Service, c cache.Cache, ttl time.Duration) Service {
+	if c == nil {
+		return inner
+	}
+	return &cachedService{inner: inner, cache: c, ttl: ttl}
+}
+
+// Synthesize 优先从缓存读取语音，未命中时调用底层服务并写入缓存
+func (s *cachedService) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	key := s.cacheKey(req)
+	if val, ok := s.cache.Get(ctx, key); ok {
+		return val, nil
+	}
+
+	audio, err := s.inner.Synthesize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(ctx, key, audio, s.ttl)
+	return audio, nil
+}
+
+// Provider 返回底层TTS提供商名称
+func (s *cachedService) Provider() string {
+	return s.inner.Provider()
+}
+
+// Moderate 将语音审核能力透传给底层服务，底层不支持时返回错误
+func (s *cachedService) Moderate(ctx context.Context, audio []byte) (ModerationResult, error) {
+	moderator, ok := s.inner.(Moderator)
+	if !ok {
+		return ModerationResult{}, fmt.Errorf("TTS提供商%s不支持语音审核", s.inner.Provider())
+	}
+	return moderator.Moderate(ctx, audio)
+}
+
+// cacheKey 以提供商+完整请求参数的SHA-256摘要作为缓存键
+func (s *cachedService) cacheKey(req SynthesisRequest) string {
+	raw := fmt.Sprintf("%s:%s:%s:%s:%s:%g:%g:%g:%s",
+		s.inner.Provider(), req.Speaker, req.Voice, req.Text, req.SSML, req.Rate, req.Pitch, req.Volume, req.Style)
+	h := sha256.Sum256([]byte(raw))
+	return "tts:" + hex.EncodeToString(h[:])
+}
@@ -0,0 +1,238 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"hacker-news/internal/audio"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultChunkLimit 是大多数Provider单次请求可接受的字符数上限的保守估计
+const defaultChunkLimit = 2000
+
+// chunkConcurrency 限制单次合成内并发分片请求的数量
+const chunkConcurrency = 4
+
+// providerChunkLimits 记录个别Provider明显更严格的单次请求字符数限制，
+// 未列出的Provider使用defaultChunkLimit
+var providerChunkLimits = map[string]int{
+	"aliyun": 300,
+}
+
+// ChunkingTTS 是TTS服务的分片装饰器：当请求内容超过底层Provider的单次
+// 长度限制时，按句子边界切分为多段，并发合成后再拼接为完整音频，
+// 使调用方无需关心各Provider的长度限制
+type ChunkingTTS struct {
+	inner Service
+	limit int
+}
+
+// WithChunking 用分片能力包装一个TTS服务，切分阈值取自该Provider在
+// providerChunkLimits中的配置，未配置时使用defaultChunkLimit
+func WithChunking(inner Service) Service {
+	limit, ok := providerChunkLimits[inner.Provider()]
+	if !ok {
+		limit = defaultChunkLimit
+	}
+	return &ChunkingTTS{inner: inner, limit: limit}
+}
+
+// Provider 返回底层TTS提供商名称
+func (c *ChunkingTTS) Provider() string {
+	return c.inner.Provider()
+}
+
+// Moderate 将语音审核能力透传给底层服务，底层不支持时返回错误
+func (c *ChunkingTTS) Moderate(ctx context.Context, audioData []byte) (ModerationResult, error) {
+	moderator, ok := c.inner.(Moderator)
+	if !ok {
+		return ModerationResult{}, fmt.Errorf("TTS提供商%s不支持语音审核", c.inner.Provider())
+	}
+	return moderator.Moderate(ctx, audioData)
+}
+
+// Synthesize 在内容未超过限制时直接委托给底层服务；超过限制时切分为多段，
+// 按chunkConcurrency并发合成后，再用internal/audio做帧级拼接，避免简单
+// 字节拼接在MP3帧边界处产生的爆音
+func (c *ChunkingTTS) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	isSSML := req.SSML != ""
+
+	content := req.Content()
+	limit := c.limit
+	if isSSML {
+		// SSML分片时每段都要补上<speak>...</speak>根标签，先扣除这部分开销，
+		// 再对<speak>内部的纯文本按句子边界切分
+		content = stripSpeakEnvelope(content)
+		limit -= speakEnvelopeOverhead
+		if limit < 1 {
+			limit = 1
+		}
+	}
+
+	chunks := splitIntoChunks(content, limit)
+	if len(chunks) <= 1 {
+		return c.inner.Synthesize(ctx, req)
+	}
+
+	audios := make([][]byte, len(chunks))
+	g, ctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, chunkConcurrency)
+
+	for idx, chunk := range chunks {
+		idx, chunk := idx, chunk
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			chunkReq := req
+			if isSSML {
+				chunkReq.SSML = wrapSpeakEnvelope(chunk)
+				chunkReq.Text = ""
+			} else {
+				chunkReq.Text = chunk
+			}
+
+			audio, err := c.inner.Synthesize(ctx, chunkReq)
+			if err != nil {
+				return fmt.Errorf("合成第%d/%d段失败: %w", idx+1, len(chunks), err)
+			}
+			audios[idx] = audio
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return stitchMP3Segments(audios), nil
+}
+
+// stitchMP3Segments 优先用internal/audio做帧级拼接，拿到无缝衔接的MP3；
+// 分片不是可识别的MP3帧流（ErrUnsupportedSegment）时回退到原始字节拼接
+func stitchMP3Segments(segments [][]byte) []byte {
+	merged, _, err := audio.Concat(segments, 0)
+	if err == nil {
+		return merged
+	}
+	if !errors.Is(err, audio.ErrUnsupportedSegment) {
+		log.Printf("拼接分片音频失败，回退到原始字节拼接: %v", err)
+	}
+
+	var fallback []byte
+	for _, segment := range segments {
+		fallback = append(fallback, segment...)
+	}
+	return fallback
+}
+
+// speakEnvelope 匹配BuildSSML生成的<speak>...</speak>根标签，用于分片前
+// 剥离、分片后给每一段重新套上，确保发给Provider的每段SSML都是完整的文档
+var speakEnvelope = regexp.MustCompile(`(?is)^\s*<speak[^>]*>(.*)</speak>\s*$`)
+
+// speakEnvelopeOverhead 是"<speak></speak>"本身占用的字符数，分片时要从
+// Provider的长度限制里预留出来，否则套上根标签后可能超限
+const speakEnvelopeOverhead = len("<speak></speak>")
+
+// stripSpeakEnvelope 剥离SSML最外层的<speak>根标签，只保留可供切分的正文；
+// 不是标准<speak>...</speak>包裹时原样返回
+func stripSpeakEnvelope(ssml string) string {
+	if m := speakEnvelope.FindStringSubmatch(ssml); m != nil {
+		return m[1]
+	}
+	return ssml
+}
+
+// wrapSpeakEnvelope 给一段SSML正文重新套上<speak>根标签
+func wrapSpeakEnvelope(inner string) string {
+	return "<speak>" + inner + "</speak>"
+}
+
+// sentenceBoundary 匹配句子结尾标点（含连续标点如"？！"）或段落换行，
+// 作为切分点；标签（如<break time="500ms"/>）不含这些字符，因此不会被截断
+var sentenceBoundary = regexp.MustCompile(`[。！？.!?]+|\n\s*\n`)
+
+// splitIntoChunks 把text按句子边界分组，使每个分片的rune数不超过limit。
+// 单句本身超过limit时按rune强制截断，避免无法收敛
+func splitIntoChunks(text string, limit int) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		if text == "" {
+			return nil
+		}
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+	}
+
+	for _, sentence := range splitSentencesKeepPunct(text) {
+		sentenceLen := utf8.RuneCountInString(sentence)
+
+		if sentenceLen > limit {
+			flush()
+			chunks = append(chunks, hardSplit(sentence, limit)...)
+			continue
+		}
+
+		if currentLen > 0 && currentLen+sentenceLen > limit {
+			flush()
+		}
+		current.WriteString(sentence)
+		currentLen += sentenceLen
+	}
+	flush()
+
+	return chunks
+}
+
+// splitSentencesKeepPunct 按句子边界切分text，结尾标点/换行保留在前一句内，
+// 与splitSentences（用于字幕，标点会被丢弃）相比更适合直接喂给TTS朗读
+func splitSentencesKeepPunct(text string) []string {
+	matches := sentenceBoundary.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return []string{text}
+	}
+
+	var sentences []string
+	start := 0
+	for _, m := range matches {
+		end := m[1]
+		sentences = append(sentences, text[start:end])
+		start = end
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+	return sentences
+}
+
+// hardSplit 把一个超过limit的句子按rune数强制切成多段
+func hardSplit(sentence string, limit int) []string {
+	runes := []rune(sentence)
+	var pieces []string
+	for len(runes) > 0 {
+		n := limit
+		if n > len(runes) {
+			n = len(runes)
+		}
+		pieces = append(pieces, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return pieces
+}
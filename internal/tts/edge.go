@@ -59,29 +59,35 @@ func NewEdgeTTS(cfg config.EdgeTTSConfig) (*EdgeTTS, error) {
 	}, nil
 }
 
-// SynthesizeSpeech 将文本转换为语音
-func (e *EdgeTTS) SynthesizeSpeech(ctx context.Context, text string, speaker string) ([]byte, error) {
-	// 根据角色获取语音ID
-	processedText := ProcessDialogueText(text)
-
-	voiceID, err := GetSpeakerVoice("edge", speaker)
+// Synthesize 按SynthesisRequest合成语音
+func (e *EdgeTTS) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	voiceID, err := resolveVoice("edge", req)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Printf("使用Edge TTS转换文本，语音ID: %s", voiceID)
 
+	speed := req.Rate
+	if speed == 0 {
+		speed = 1.0
+	}
+	volume := req.Volume
+	if volume == 0 {
+		volume = 1.0
+	}
+
 	// 默认请求参数
 	request := TTSRequest{
 		Model:  "edge-tts",
-		Text:   text,
+		Text:   req.Content(),
 		Stream: false,
 		GetSRT: false,
 		VoiceSetting: VoiceSetting{
 			VoiceID: voiceID,
-			Speed:   1.0,
-			Volume:  1.0,
-			Pitch:   0.0,
+			Speed:   speed,
+			Volume:  volume,
+			Pitch:   req.Pitch,
 		},
 		AudioSetting: AudioSetting{
 			Format:     e.outputFormat,
@@ -185,7 +191,7 @@ func (e *EdgeTTS) callTTSAPIWithSRT(ctx context.Context, request TTSRequest) ([]
 		log.Printf("SRT字幕获取成功，大小: %d 字节", len(srtBytes))
 	} else if request.GetSRT {
 		// 如果API没有返回SRT但请求中要求了SRT，则本地生成
-		srt, err := generateSRT(request.Text)
+		srt, err := generateSRT(request.Text, audio)
 		if err != nil {
 			log.Printf("本地生成SRT字幕失败: %v", err)
 		} else {
@@ -207,34 +213,137 @@ func escapeXML(text string) string {
 	return text
 }
 
-// generateSRT 生成SRT字幕
-func generateSRT(text string) (string, error) {
-	// 简单的字幕生成逻辑
-	// 这里使用一个简单的算法：每个汉字约0.3秒，每个标点符号约0.5秒
-	var srtBuilder strings.Builder
-	
-	// 分割文本为句子
+// BuildSSML 把多个文本片段拼接为一段SSML，片段之间插入gap时长的静音停顿，
+// 供调用方构造SynthesisRequest.SSML（例如在日报播客的各条故事之间插入停顿）
+func BuildSSML(sections []string, gap time.Duration) string {
+	var sb strings.Builder
+	sb.WriteString("<speak>")
+	for i, section := range sections {
+		if i > 0 && gap > 0 {
+			fmt.Fprintf(&sb, `<break time="%dms"/>`, gap.Milliseconds())
+		}
+		sb.WriteString(escapeXML(section))
+	}
+	sb.WriteString("</speak>")
+	return sb.String()
+}
+
+// generateSRT 为一段文本生成SRT字幕。优先用MP3Duration测出audio的真实时长，
+// 再按句子权重（字符数，CJK标点略高）成比例切分；audio无法识别为MP3帧流时
+// （如audio为空或编码不是MP3），回退到按字符数估算时长的启发式算法
+func generateSRT(text string, audio []byte) (string, error) {
 	sentences := splitSentences(text)
-	
-	startTime := 0.0
+	durations := segmentDurations(sentences, audio)
+
+	entries, _ := buildSRTEntries(sentences, durations, 0, 0)
+	return entries, nil
+}
+
+// segmentDurations 为一组句子分配各自的时长：能测出audio真实时长时按权重比例切分，
+// 否则回退到calculateDuration的启发式估算
+func segmentDurations(sentences []string, audio []byte) []time.Duration {
+	if total, err := MP3Duration(audio); err == nil && total > 0 {
+		return distributeDuration(sentences, total)
+	}
+
+	durations := make([]time.Duration, len(sentences))
+	for i, s := range sentences {
+		durations[i] = time.Duration(calculateDuration(s) * float64(time.Second))
+	}
+	return durations
+}
+
+// distributeDuration 把total按各句子的权重（sentenceWeight）成比例分配
+func distributeDuration(sentences []string, total time.Duration) []time.Duration {
+	weights := make([]float64, len(sentences))
+	sumWeights := 0.0
+	for i, s := range sentences {
+		weights[i] = sentenceWeight(s)
+		sumWeights += weights[i]
+	}
+
+	durations := make([]time.Duration, len(sentences))
+	if sumWeights <= 0 {
+		return durations
+	}
+	for i, w := range weights {
+		durations[i] = time.Duration(float64(total) * w / sumWeights)
+	}
+	return durations
+}
+
+// sentenceWeight 以句子的字符数为基础权重，CJK标点（顿号、书名号、引号等）
+// 朗读时通常伴随更明显的停顿，权重略高于普通字符
+func sentenceWeight(sentence string) float64 {
+	weight := 0.0
+	for _, r := range sentence {
+		if isCJKPunct(r) {
+			weight += 1.5
+		} else {
+			weight += 1.0
+		}
+	}
+	if weight <= 0 {
+		weight = 1
+	}
+	return weight
+}
+
+// isCJKPunct 判断字符是否为常见中文标点
+func isCJKPunct(r rune) bool {
+	switch r {
+	case '，', '。', '！', '？', '；', '：', '、', '“', '”', '‘', '’', '（', '）', '《', '》':
+		return true
+	default:
+		return false
+	}
+}
+
+// buildSRTEntries 把句子和对应时长渲染成SRT文本，序号从startIndex+1开始，
+// 时间戳从startOffset开始累加，返回渲染结果与该片段结束时的时间偏移（用于拼接下一段）
+func buildSRTEntries(sentences []string, durations []time.Duration, startIndex int, startOffset time.Duration) (string, time.Duration) {
+	var srtBuilder strings.Builder
+	cursor := startOffset
+
 	for i, sentence := range sentences {
-		// 计算句子持续时间（秒）
-		duration := calculateDuration(sentence)
-		endTime := startTime + duration
-		
-		// 格式化时间
-		startTimeStr := formatSRTTime(startTime)
-		endTimeStr := formatSRTTime(endTime)
-		
-		// 写入SRT条目
-		srtBuilder.WriteString(fmt.Sprintf("%d\n", i+1))
-		srtBuilder.WriteString(fmt.Sprintf("%s --> %s\n", startTimeStr, endTimeStr))
+		end := cursor + durations[i]
+
+		srtBuilder.WriteString(fmt.Sprintf("%d\n", startIndex+i+1))
+		srtBuilder.WriteString(fmt.Sprintf("%s --> %s\n", formatSRTTime(cursor.Seconds()), formatSRTTime(end.Seconds())))
 		srtBuilder.WriteString(fmt.Sprintf("%s\n\n", sentence))
-		
-		startTime = endTime
+
+		cursor = end
 	}
-	
-	return srtBuilder.String(), nil
+
+	return srtBuilder.String(), cursor
+}
+
+// SRTSegment 是拼接完整播客字幕时的一个对话片段：Text是去除说话人前缀后的台词，
+// Audio是该片段合成的MP3音频，用于测算真实时长
+type SRTSegment struct {
+	Text  string
+	Audio []byte
+}
+
+// BuildPodcastSRT 把多个对话片段的字幕按顺序拼接为完整播客的SRT文本。
+// 每个片段的时间戳在上一个片段结束时间的基础上累加，与合并后的音频保持对齐
+func BuildPodcastSRT(segments []SRTSegment) string {
+	var full strings.Builder
+	index := 0
+	offset := time.Duration(0)
+
+	for _, seg := range segments {
+		sentences := splitSentences(seg.Text)
+		durations := segmentDurations(sentences, seg.Audio)
+
+		entries, end := buildSRTEntries(sentences, durations, index, offset)
+		full.WriteString(entries)
+
+		index += len(sentences)
+		offset = end
+	}
+
+	return full.String()
 }
 
 // splitSentences 将文本分割为句子
@@ -0,0 +1,243 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hacker-news/config"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	iflytekHost = "tts-api.xfyun.cn"
+	iflytekPath = "/v2/tts"
+)
+
+// IFlytekTTS 基于科大讯飞WebSocket语音合成API实现TTS服务
+type IFlytekTTS struct {
+	config config.IFlytekTTSConfig
+}
+
+// NewIFlytekTTS 创建一个新的科大讯飞TTS服务
+func NewIFlytekTTS(cfg config.IFlytekTTSConfig) (*IFlytekTTS, error) {
+	return &IFlytekTTS{config: cfg}, nil
+}
+
+// Provider 返回TTS提供商名称
+func (i *IFlytekTTS) Provider() string {
+	return "iflytek"
+}
+
+// iflytekRequest 是发往讯飞TTS WebSocket的一次性合成请求帧
+type iflytekRequest struct {
+	Common   iflytekCommon   `json:"common"`
+	Business iflytekBusiness `json:"business"`
+	Data     iflytekData     `json:"data"`
+}
+
+type iflytekCommon struct {
+	AppID string `json:"app_id"`
+}
+
+type iflytekBusiness struct {
+	Aue    string `json:"aue"` // 音频编码，lame表示输出mp3
+	Vcn    string `json:"vcn"` // 发音人
+	Speed  int    `json:"speed"`
+	Volume int    `json:"volume"`
+	Pitch  int    `json:"pitch"`
+	Tte    string `json:"tte"` // 文本编码
+}
+
+type iflytekData struct {
+	Status int    `json:"status"` // 2表示一次性文本，不分帧发送
+	Text   string `json:"text"`   // base64编码的待合成文本
+}
+
+// iflytekResponse 是讯飞TTS WebSocket返回的一帧响应
+type iflytekResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Sid     string `json:"sid"`
+	Data    struct {
+		Audio  string `json:"audio"`
+		Status int    `json:"status"` // 2表示合成结束
+	} `json:"data"`
+}
+
+// Synthesize 按SynthesisRequest合成语音，内部复用流式接口并把所有分片拼接成完整音频
+func (i *IFlytekTTS) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	voiceName, err := resolveVoice("iflytek", req)
+	if err != nil {
+		return nil, err
+	}
+
+	audioCh, err := i.synthesizeStream(ctx, req.Content(), voiceName, req.Rate, req.Pitch, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for chunk := range audioCh {
+		buf.Write(chunk)
+	}
+
+	if buf.Len() == 0 {
+		return nil, fmt.Errorf("讯飞TTS未返回任何音频数据")
+	}
+
+	log.Printf("讯飞TTS转换成功，音频大小: %d 字节", buf.Len())
+	return buf.Bytes(), nil
+}
+
+// SynthesizeSpeechStream 以流式方式合成语音，返回的channel会随着WebSocket
+// 收到的音频分片持续写入，调用方可以在合成完成前就开始落盘/上传，
+// channel在合成结束或连接出错时关闭
+func (i *IFlytekTTS) SynthesizeSpeechStream(ctx context.Context, text string, speaker string) (<-chan []byte, error) {
+	voiceName, err := i.voiceForSpeaker(speaker)
+	if err != nil {
+		return nil, err
+	}
+	return i.synthesizeStream(ctx, text, voiceName, 0, 0, 0)
+}
+
+// synthesizeStream 是流式合成的实际实现，rate/pitch/volume为0时使用讯飞的默认值50
+func (i *IFlytekTTS) synthesizeStream(ctx context.Context, text string, voiceName string, rate, pitch, volume float64) (<-chan []byte, error) {
+	wsURL, err := i.signedWebSocketURL()
+	if err != nil {
+		return nil, fmt.Errorf("生成签名URL失败: %w", err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("连接讯飞TTS WebSocket失败: %w", err)
+	}
+
+	if rate == 0 {
+		rate = 50
+	}
+	if pitch == 0 {
+		pitch = 50
+	}
+	if volume == 0 {
+		volume = 50
+	}
+
+	req := iflytekRequest{
+		Common: iflytekCommon{AppID: i.config.AppID},
+		Business: iflytekBusiness{
+			Aue:    "lame",
+			Vcn:    voiceName,
+			Speed:  int(rate),
+			Volume: int(volume),
+			Pitch:  int(pitch),
+			Tte:    "UTF8",
+		},
+		Data: iflytekData{
+			Status: 2,
+			Text:   base64.StdEncoding.EncodeToString([]byte(text)),
+		},
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("序列化合成请求失败: %w", err)
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("发送合成请求失败: %w", err)
+	}
+
+	audioCh := make(chan []byte, 8)
+	go readIFlytekStream(conn, audioCh)
+	return audioCh, nil
+}
+
+// readIFlytekStream 持续读取WebSocket帧，解码出音频分片写入audioCh，
+// 遇到status=2（合成结束）或任何错误都会关闭连接和channel
+func readIFlytekStream(conn *websocket.Conn, audioCh chan<- []byte) {
+	defer close(audioCh)
+	defer conn.Close()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			log.Printf("读取讯飞TTS响应失败: %v", err)
+			return
+		}
+
+		var resp iflytekResponse
+		if err := json.Unmarshal(message, &resp); err != nil {
+			log.Printf("解析讯飞TTS响应失败: %v", err)
+			return
+		}
+
+		if resp.Code != 0 {
+			log.Printf("讯飞TTS返回错误: %d %s", resp.Code, resp.Message)
+			return
+		}
+
+		if resp.Data.Audio != "" {
+			audio, err := base64.StdEncoding.DecodeString(resp.Data.Audio)
+			if err != nil {
+				log.Printf("解码讯飞TTS音频失败: %v", err)
+				return
+			}
+			audioCh <- audio
+		}
+
+		if resp.Data.Status == 2 {
+			return
+		}
+	}
+}
+
+// voiceForSpeaker 优先使用配置里固定的发音人，未配置时按角色回退到讯飞的默认发音人
+func (i *IFlytekTTS) voiceForSpeaker(speaker string) (string, error) {
+	if speaker != "男" && speaker != "女" {
+		return "", fmt.Errorf("无效的角色，必须是'男'或'女'")
+	}
+
+	if i.config.VoiceName != "" {
+		return i.config.VoiceName, nil
+	}
+	if speaker == "男" {
+		return "aisjiuxu", nil
+	}
+	return "xiaoyan", nil
+}
+
+// signedWebSocketURL 按讯飞的鉴权规则组装带签名的WebSocket连接地址：
+// 对"host/date/请求行"组成的字符串做HMAC-SHA256签名，再包装进authorization参数
+func (i *IFlytekTTS) signedWebSocketURL() (string, error) {
+	date := time.Now().UTC().Format(http.TimeFormat)
+
+	signatureOrigin := fmt.Sprintf("host: %s\ndate: %s\nGET %s HTTP/1.1", iflytekHost, date, iflytekPath)
+
+	mac := hmac.New(sha256.New, []byte(i.config.APISecret))
+	mac.Write([]byte(signatureOrigin))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	authOrigin := fmt.Sprintf(
+		`api_key="%s", algorithm="hmac-sha256", headers="host date request-line", signature="%s"`,
+		i.config.APIKey, signature,
+	)
+	authorization := base64.StdEncoding.EncodeToString([]byte(authOrigin))
+
+	q := url.Values{}
+	q.Set("authorization", authorization)
+	q.Set("date", date)
+	q.Set("host", iflytekHost)
+
+	return fmt.Sprintf("wss://%s%s?%s", iflytekHost, iflytekPath, q.Encode()), nil
+}
@@ -0,0 +1,12 @@
+package tts
+
+import (
+	"hacker-news/internal/audio"
+	"time"
+)
+
+// MP3Duration 解析MP3帧头得到精确时长，对VBR安全（逐帧累加真实采样时长，
+// 不假设恒定比特率）。音频不是可识别的MP3帧流时返回错误，调用方应回退到文本长度估算
+func MP3Duration(data []byte) (time.Duration, error) {
+	return audio.Duration(data)
+}
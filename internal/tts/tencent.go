@@ -0,0 +1,268 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hacker-news/config"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TencentTTS 实现腾讯云TTS服务，同时提供基于GME语音审核接口的Moderate能力
+type TencentTTS struct {
+	config config.TencentTTSConfig
+}
+
+// NewTencentTTS 创建一个新的腾讯云TTS服务
+func NewTencentTTS(cfg config.TencentTTSConfig) (*TencentTTS, error) {
+	return &TencentTTS{
+		config: cfg,
+	}, nil
+}
+
+// Synthesize 按SynthesisRequest合成语音
+func (t *TencentTTS) Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error) {
+	voiceType, err := resolveVoice("tencent", req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("使用腾讯云TTS转换文本，语音ID: %s", voiceType)
+
+	body := map[string]interface{}{
+		"Text":       req.Content(),
+		"SessionId":  fmt.Sprintf("%d", time.Now().UnixNano()),
+		"VoiceType":  mustParseVoiceType(voiceType),
+		"Codec":      "mp3",
+		"SampleRate": 16000,
+	}
+	if req.Rate != 0 {
+		body["Speed"] = req.Rate
+	}
+	if req.Volume != 0 {
+		body["Volume"] = req.Volume
+	}
+
+	var result struct {
+		Response struct {
+			Audio     string `json:"Audio"`
+			SessionId string `json:"SessionId"`
+			Error     *struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"Error"`
+		} `json:"Response"`
+	}
+
+	if err := t.call(ctx, "tts.tencentcloudapi.com", "2019-08-23", "TextToVoice", body, &result); err != nil {
+		return nil, fmt.Errorf("腾讯云TTS请求失败: %w", err)
+	}
+	if result.Response.Error != nil {
+		return nil, fmt.Errorf("腾讯云TTS请求失败: %s", result.Response.Error.Message)
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(result.Response.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("解码音频数据失败: %w", err)
+	}
+
+	log.Printf("TTS转换成功，音频大小: %d 字节", len(audio))
+	return audio, nil
+}
+
+// Provider 返回TTS提供商名称
+func (t *TencentTTS) Provider() string {
+	return "tencent"
+}
+
+// ModerationResult 是GME语音审核的结果，Categories为各违规类别的置信度(0-1)
+type ModerationResult struct {
+	Categories map[string]float64 // 例如 "politics"、"porn"、"abuse"
+	Flagged    bool               // 是否存在类别超过配置的ModerationThreshold
+}
+
+// Moderate 将音频提交给GME语音审核接口，检测涉政、涉黄、辱骂等违规内容，
+// 任务是异步的：先提交后轮询结果，直至审核完成或超时
+func (t *TencentTTS) Moderate(ctx context.Context, audio []byte) (ModerationResult, error) {
+	var submitResult struct {
+		Response struct {
+			TaskId int64 `json:"TaskId"`
+			Error  *struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"Error"`
+		} `json:"Response"`
+	}
+
+	submitBody := map[string]interface{}{
+		"AppId":   t.config.AppID,
+		"Content": base64.StdEncoding.EncodeToString(audio),
+		"DataId":  fmt.Sprintf("podcast-%d", time.Now().UnixNano()),
+	}
+	if err := t.call(ctx, "gme.tencentcloudapi.com", "2018-07-11", "VoiceFilter", submitBody, &submitResult); err != nil {
+		return ModerationResult{}, fmt.Errorf("提交语音审核任务失败: %w", err)
+	}
+	if submitResult.Response.Error != nil {
+		return ModerationResult{}, fmt.Errorf("提交语音审核任务失败: %s", submitResult.Response.Error.Message)
+	}
+
+	// 轮询审核结果，GME的审核是异步任务
+	const maxPolls = 10
+	for i := 0; i < maxPolls; i++ {
+		select {
+		case <-ctx.Done():
+			return ModerationResult{}, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+
+		var describeResult struct {
+			Response struct {
+				Status     int    `json:"Status"` // 0: 审核中, 1: 完成
+				Suggestion string `json:"Suggestion"`
+				Labels     []struct {
+					Label      string  `json:"Label"`
+					Confidence float64 `json:"Confidence"`
+				} `json:"Labels"`
+				Error *struct {
+					Code    string `json:"Code"`
+					Message string `json:"Message"`
+				} `json:"Error"`
+			} `json:"Response"`
+		}
+
+		describeBody := map[string]interface{}{
+			"AppId":  t.config.AppID,
+			"TaskId": submitResult.Response.TaskId,
+		}
+		if err := t.call(ctx, "gme.tencentcloudapi.com", "2018-07-11", "DescribeFilterResult", describeBody, &describeResult); err != nil {
+			return ModerationResult{}, fmt.Errorf("查询语音审核结果失败: %w", err)
+		}
+		if describeResult.Response.Error != nil {
+			return ModerationResult{}, fmt.Errorf("查询语音审核结果失败: %s", describeResult.Response.Error.Message)
+		}
+		if describeResult.Response.Status != 1 {
+			continue
+		}
+
+		categories := make(map[string]float64, len(describeResult.Response.Labels))
+		flagged := false
+		for _, l := range describeResult.Response.Labels {
+			categories[strings.ToLower(l.Label)] = l.Confidence
+			if l.Confidence > t.config.ModerationThreshold {
+				flagged = true
+			}
+		}
+
+		return ModerationResult{Categories: categories, Flagged: flagged}, nil
+	}
+
+	return ModerationResult{}, fmt.Errorf("语音审核超时，未在预期时间内完成")
+}
+
+// call 使用TC3-HMAC-SHA256签名向腾讯云API发起POST请求
+func (t *TencentTTS) call(ctx context.Context, host, version, action string, body map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("序列化请求体失败: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	authorization := t.sign(host, action, version, payload, timestamp)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Host", host)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", version)
+	req.Header.Set("X-TC-Timestamp", fmt.Sprintf("%d", timestamp))
+	req.Header.Set("X-TC-Region", t.config.Region)
+	req.Header.Set("Authorization", authorization)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("请求失败，状态码: %d，响应: %s", resp.StatusCode, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	return nil
+}
+
+// sign 按照腾讯云TC3-HMAC-SHA256规范计算Authorization请求头
+func (t *TencentTTS) sign(host, action, version string, payload []byte, timestamp int64) string {
+	algorithm := "TC3-HMAC-SHA256"
+	service := strings.Split(host, ".")[0]
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+
+	// 步骤1：拼接规范请求串
+	canonicalRequest := strings.Join([]string{
+		"POST",
+		"/",
+		"",
+		"content-type:application/json; charset=utf-8\n" + "host:" + host + "\n",
+		"content-type;host",
+		hashHex(payload),
+	}, "\n")
+
+	// 步骤2：拼接待签名字符串
+	credentialScope := fmt.Sprintf("%s/%s/tc3_request", date, service)
+	stringToSign := strings.Join([]string{
+		algorithm,
+		fmt.Sprintf("%d", timestamp),
+		credentialScope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	// 步骤3：逐级派生签名密钥并计算签名
+	secretDate := hmacSHA256([]byte("TC3"+t.config.SecretKey), date)
+	secretService := hmacSHA256(secretDate, service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		algorithm, t.config.SecretId, credentialScope, signature)
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// mustParseVoiceType 将字符串形式的语音ID解析为腾讯云TTS所需的整型VoiceType，解析失败时返回默认音色
+func mustParseVoiceType(voiceID string) int {
+	var voiceType int
+	if _, err := fmt.Sscanf(voiceID, "%d", &voiceType); err != nil {
+		return 101001 // 默认：智瑜，温暖女声
+	}
+	return voiceType
+}
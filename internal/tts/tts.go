@@ -4,25 +4,79 @@ import (
 	"context"
 	"fmt"
 	"hacker-news/config"
+	"hacker-news/internal/cache"
+	"time"
 )
 
 // Service 定义TTS服务接口
 type Service interface {
-	// SynthesizeSpeech 将文本转换为语音
-	SynthesizeSpeech(ctx context.Context, text string, speaker string) ([]byte, error)
-	
+	// Synthesize 按SynthesisRequest描述的内容、语音和参数合成语音
+	Synthesize(ctx context.Context, req SynthesisRequest) ([]byte, error)
+
 	// Provider 返回TTS提供商名称
 	Provider() string
 }
 
-// Factory 创建TTS服务
-func Factory(cfg *config.TTSConfig) (Service, error) {
-	// 根据配置选择TTS服务
+// SynthesisRequest 描述一次语音合成请求
+type SynthesisRequest struct {
+	Text string // 纯文本
+	SSML string // 带<break>等标签的SSML，非空时优先于Text
+
+	Speaker string // "男"或"女"，Voice为空时据此按Provider推导默认语音
+	Voice   string // 显式指定语音ID，优先于Speaker
+
+	Rate   float64 // 语速，0表示使用各Provider的默认值
+	Pitch  float64 // 语调，0表示使用各Provider的默认值
+	Volume float64 // 音量，0表示使用各Provider的默认值
+	Style  string  // 风格，如"news"、"cheerful"，Provider不支持时忽略
+}
+
+// Content 返回本次合成要朗读的内容：SSML非空时优先使用SSML，否则使用Text
+func (r SynthesisRequest) Content() string {
+	if r.SSML != "" {
+		return r.SSML
+	}
+	return r.Text
+}
+
+// resolveVoice 优先使用请求显式指定的Voice，未指定时按Speaker（"男"/"女"）
+// 回退到该Provider的默认语音
+func resolveVoice(provider string, req SynthesisRequest) (string, error) {
+	if req.Voice != "" {
+		return req.Voice, nil
+	}
+	return GetSpeakerVoice(provider, req.Speaker)
+}
+
+// Moderator 是可选的语音审核能力，由支持内容审核的TTS提供商（如腾讯云GME）实现。
+// Factory返回的包装服务（ChunkingTTS、缓存装饰器）总是实现该接口，
+// 底层Provider不支持审核时其Moderate返回错误
+type Moderator interface {
+	Moderate(ctx context.Context, audio []byte) (ModerationResult, error)
+}
+
+// Factory 创建TTS服务。原始Provider会先经过ChunkingTTS包装以支持超出
+// 单次请求字符数限制的长文本，再经过缓存装饰器包装（如果c非nil）：
+// 相同请求内容的合成结果将被缓存ttl时长，避免重复调用TTS接口
+func Factory(cfg *config.TTSConfig, c cache.Cache, ttl time.Duration) (Service, error) {
+	service, err := newRawService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return WithCache(WithChunking(service), c, ttl), nil
+}
+
+// newRawService 根据配置选择未经缓存包装的TTS服务
+func newRawService(cfg *config.TTSConfig) (Service, error) {
 	switch cfg.Provider {
 	case "edge":
 		return NewEdgeTTS(cfg.EdgeTTS)
 	case "aliyun":
 		return NewAliyunTTS(cfg.AliyunTTS)
+	case "tencent":
+		return NewTencentTTS(cfg.TencentTTS)
+	case "iflytek":
+		return NewIFlytekTTS(cfg.IFlytekTTS)
 	default:
 		// 默认使用Edge TTS
 		return NewEdgeTTS(cfg.EdgeTTS)
@@ -48,6 +102,16 @@ func GetSpeakerVoice(provider string, speaker string) (string, error) {
 			return "aixia", nil // 阿里云男声
 		}
 		return "xiaoyun", nil // 阿里云女声
+	case "tencent":
+		if speaker == "男" {
+			return "101002", nil // 腾讯云男声：智云
+		}
+		return "101001", nil // 腾讯云女声：智瑜
+	case "iflytek":
+		if speaker == "男" {
+			return "aisjiuxu", nil // 讯飞男声
+		}
+		return "xiaoyan", nil // 讯飞女声
 	default:
 		// 默认使用Edge TTS
 		if speaker == "男" {